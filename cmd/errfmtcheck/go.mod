@@ -0,0 +1,15 @@
+module github.com/crypto-bundle/bc-wallet-common-lib-errors/cmd/errfmtcheck
+
+go 1.22.0
+
+require (
+	github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errfmtcheck v0.0.0-00010101000000-000000000000
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+)
+
+replace github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errfmtcheck => ../../pkg/errfmtcheck
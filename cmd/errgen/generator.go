@@ -0,0 +1,279 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const goTemplate = `// Code generated by errgen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"errors"
+{{- if .IsFmtUsed }}
+	"fmt"
+{{- end }}
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+// Scopes of errors.
+const (
+{{- range .Scopes }}
+	Scope{{ .GoName }} = {{ quote .Name }}
+{{- end }}
+)
+
+// Codes and public codes of errors.
+const (
+{{- range .Errors }}
+	Code{{ .Name }} = {{ .Code }}
+{{- if gt .PublicCode 0 }}
+	PublicCode{{ .Name }} = {{ .PublicCode }}
+{{- end }}
+{{- end }}
+)
+
+// Sentinel errors. Errors of typed constructors wrap sentinel errors.
+var (
+{{- range .Errors }}
+	Err{{ .Name }} = errors.New({{ quote .Text }})
+{{- end }}
+)
+
+// Catalog of error codes. Formatters of typed constructors take public codes and severities of errors
+// from catalog, catalog can be passed to WithCatalog options of httperr and grpcerr packages.
+var Catalog = errformatter.NewCatalog().MustRegister(
+{{- range .Errors }}
+	errformatter.CodeInfo{
+		Code:        Code{{ .Name }},
+		Name:        {{ quote .Name }},
+		Description: {{ quote .Description }},
+		PublicCode:  {{ .PublicCode }},
+		HTTPStatus:  {{ .HTTPStatus }},
+		GRPCCode:    {{ .GRPCCode }},
+		Severity:    {{ .SeverityConst }},
+	},
+{{- end }}
+)
+
+var (
+{{- range .Errors }}
+	errFmt{{ .Name }} = errformatter.New(
+		errformatter.WithValues(
+			errformatter.NewValue(errformatter.KindScope, Scope{{ .ScopeGoName }}),
+			errformatter.NewValue(errformatter.KindCode, Code{{ .Name }}),
+		),
+		errformatter.WithCatalog(Catalog),
+	)
+{{- end }}
+)
+{{ range .Errors }}
+// New{{ .Name }}Error returns error with scope {{ .ScopeName }} and code {{ .Code }}.
+{{- if .Description }}
+// {{ .Description }}
+{{- end }}
+func New{{ .Name }}Error({{ .Signature }}) error {
+{{- if .Message }}
+	return errFmt{{ .Name }}.Error(Err{{ .Name }}, {{ .SprintfCall }})
+{{- else }}
+	return errFmt{{ .Name }}.Error(Err{{ .Name }})
+{{- end }}
+}
+{{ end -}}
+`
+
+type templateData struct {
+	Package   string
+	IsFmtUsed bool
+	Scopes    []ScopeSpec
+	Errors    []templateError
+}
+
+type templateError struct {
+	ErrorSpec
+
+	ScopeName     string
+	ScopeGoName   string
+	SeverityConst string
+	Signature     string
+	SprintfCall   string
+}
+
+// catalogEntry - entry of markdown and json catalog of errors...
+type catalogEntry struct {
+	Name        string      `json:"name"`
+	Scope       string      `json:"scope"`
+	Code        int         `json:"code"`
+	PublicCode  int         `json:"public_code,omitempty"`
+	HTTPStatus  int         `json:"http_status,omitempty"`
+	GRPCCode    uint32      `json:"grpc_code,omitempty"`
+	Severity    string      `json:"severity,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Text        string      `json:"text"`
+	Message     string      `json:"message,omitempty"`
+	Params      []ParamSpec `json:"params,omitempty"`
+}
+
+func newTemplateData(spec *Spec) (*templateData, error) {
+	data := &templateData{
+		Package:   spec.Package,
+		IsFmtUsed: false,
+		Scopes:    spec.Scopes,
+		Errors:    make([]templateError, 0),
+	}
+
+	for i := range spec.Scopes {
+		for _, errSpec := range spec.Scopes[i].Errors {
+			messageFmt, err := errSpec.format()
+			if err != nil {
+				return nil, err
+			}
+
+			params := make([]string, len(errSpec.Params))
+			for j := range errSpec.Params {
+				params[j] = errSpec.Params[j].Name + " " + errSpec.Params[j].Type
+			}
+
+			sprintfArgs := append([]string{strconv.Quote(messageFmt.Format)}, messageFmt.Args...)
+
+			data.Errors = append(data.Errors, templateError{
+				ErrorSpec:     errSpec,
+				ScopeName:     spec.Scopes[i].Name,
+				ScopeGoName:   spec.Scopes[i].GoName,
+				SeverityConst: severityConstants[errSpec.Severity],
+				Signature:     strings.Join(params, ", "),
+				SprintfCall:   "fmt.Sprintf(" + strings.Join(sprintfArgs, ", ") + ")",
+			})
+
+			data.IsFmtUsed = data.IsFmtUsed || errSpec.Message != ""
+		}
+	}
+
+	return data, nil
+}
+
+func generateGo(spec *Spec) ([]byte, error) {
+	data, err := newTemplateData(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("errors").Funcs(template.FuncMap{
+		"quote": strconv.Quote,
+	}).Parse(goTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template: %w", err)
+	}
+
+	var buffer bytes.Buffer
+
+	err = tmpl.Execute(&buffer, data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute template: %w", err)
+	}
+
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to format generated code: %w", err)
+	}
+
+	return source, nil
+}
+
+func catalogEntries(spec *Spec) []catalogEntry {
+	entries := make([]catalogEntry, 0)
+
+	for i := range spec.Scopes {
+		for _, errSpec := range spec.Scopes[i].Errors {
+			entries = append(entries, catalogEntry{
+				Name:        errSpec.Name,
+				Scope:       spec.Scopes[i].Name,
+				Code:        errSpec.Code,
+				PublicCode:  errSpec.PublicCode,
+				HTTPStatus:  errSpec.HTTPStatus,
+				GRPCCode:    errSpec.GRPCCode,
+				Severity:    errSpec.Severity,
+				Description: errSpec.Description,
+				Text:        errSpec.Text,
+				Message:     errSpec.Message,
+				Params:      errSpec.Params,
+			})
+		}
+	}
+
+	return entries
+}
+
+func generateJSONCatalog(spec *Spec) ([]byte, error) {
+	data, err := json.MarshalIndent(catalogEntries(spec), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal catalog: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+func generateMarkdownCatalog(spec *Spec) []byte {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("# Errors of package " + spec.Package + "\n\n")
+	buffer.WriteString("| Code | Name | Scope | Public code | HTTP status | gRPC code | Severity | Description |\n")
+	buffer.WriteString("|------|------|-------|-------------|-------------|-----------|----------|-------------|\n")
+
+	for _, entry := range catalogEntries(spec) {
+		fmt.Fprintf(&buffer, "| %d | %s | %s | %s | %s | %s | %s | %s |\n",
+			entry.Code, entry.Name, entry.Scope,
+			optionalNumber(entry.PublicCode), optionalNumber(entry.HTTPStatus),
+			optionalNumber(int(entry.GRPCCode)), entry.Severity,
+			strings.ReplaceAll(entry.Description, "|", "\\|"))
+	}
+
+	return buffer.Bytes()
+}
+
+func optionalNumber(number int) string {
+	if number == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(number)
+}
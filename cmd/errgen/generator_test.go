@@ -0,0 +1,126 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerator(t *testing.T) {
+	t.Run("generated code and catalogs equal with golden files", func(t *testing.T) {
+		outDir := t.TempDir()
+
+		err := run(filepath.Join("testdata", "errors.yaml"),
+			filepath.Join(outDir, "errors_gen.go"),
+			filepath.Join(outDir, "errors.md"),
+			filepath.Join(outDir, "errors.json"),
+		)
+		if err != nil {
+			t.Fatalf("unexpected generation error: %s", err)
+		}
+
+		for _, fileName := range []string{"errors_gen.go", "errors.md", "errors.json"} {
+			generated, readErr := os.ReadFile(filepath.Join(outDir, fileName))
+			if readErr != nil {
+				t.Fatalf("unable to read generated file: %s", readErr)
+			}
+
+			golden, readErr := os.ReadFile(filepath.Join("testdata", "walleterrors", fileName))
+			if readErr != nil {
+				t.Fatalf("unable to read golden file: %s", readErr)
+			}
+
+			if string(generated) != string(golden) {
+				t.Errorf("generated file %s not equal with golden file. current:\n%s\nexpected:\n%s",
+					fileName, generated, golden)
+			}
+		}
+	})
+
+	t.Run("json spec - same result as yaml spec", func(t *testing.T) {
+		specPath := filepath.Join(t.TempDir(), "errors.json")
+
+		err := os.WriteFile(specPath, []byte(`{"package":"walleterrors","scopes":[{"name":"wallet_signer",`+
+			`"errors":[{"name":"HSMUnreachable","code":503}]}]}`), 0o600)
+		if err != nil {
+			t.Fatalf("unable to write spec: %s", err)
+		}
+
+		spec, err := readSpec(specPath)
+		if err != nil {
+			t.Fatalf("unexpected spec error: %s", err)
+		}
+
+		if err = spec.validate(); err != nil {
+			t.Fatalf("unexpected validation error: %s", err)
+		}
+
+		if spec.Scopes[0].GoName != "WalletSigner" || spec.Scopes[0].Errors[0].Text != "hsm unreachable" {
+			t.Errorf("spec defaults not equal with expected. current: %+v", spec.Scopes[0])
+		}
+	})
+
+	t.Run("invalid specs", func(t *testing.T) {
+		specs := map[string]Spec{
+			"invalid package": {Package: "wallet-errors"},
+			"duplicate code": {Package: "walleterrors", Scopes: []ScopeSpec{{Name: "scope", Errors: []ErrorSpec{
+				{Name: "First", Code: 1}, {Name: "Second", Code: 1},
+			}}}},
+			"not declared param": {Package: "walleterrors", Scopes: []ScopeSpec{{Name: "scope", Errors: []ErrorSpec{
+				{Name: "First", Code: 1, Message: "key {keyID} not found"},
+			}}}},
+			"unknown severity": {Package: "walleterrors", Scopes: []ScopeSpec{{Name: "scope", Errors: []ErrorSpec{
+				{Name: "First", Code: 1, Severity: "fatal"},
+			}}}},
+			"duplicate scope go name": {Package: "walleterrors", Scopes: []ScopeSpec{
+				{Name: "wallet_signer", Errors: []ErrorSpec{{Name: "First", Code: 1}}},
+				{Name: "wallet-signer", Errors: []ErrorSpec{{Name: "Second", Code: 2}}},
+			}},
+			"param shadows fmt import": {Package: "walleterrors", Scopes: []ScopeSpec{{Name: "scope", Errors: []ErrorSpec{
+				{Name: "First", Code: 1, Message: "{fmt}", Params: []ParamSpec{{Name: "fmt", Type: "string"}}},
+			}}}},
+			"param shadows errors import": {Package: "walleterrors", Scopes: []ScopeSpec{{Name: "scope", Errors: []ErrorSpec{
+				{Name: "First", Code: 1, Message: "{errors}", Params: []ParamSpec{{Name: "errors", Type: "int"}}},
+			}}}},
+		}
+
+		for name, spec := range specs {
+			if err := spec.validate(); !errors.Is(err, errInvalidSpec) {
+				t.Errorf("spec %s must be invalid. current: %v", name, err)
+			}
+		}
+	})
+}
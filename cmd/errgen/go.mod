@@ -0,0 +1,5 @@
+module github.com/crypto-bundle/bc-wallet-common-lib-errors/cmd/errgen
+
+go 1.22.0
+
+require gopkg.in/yaml.v3 v3.0.1
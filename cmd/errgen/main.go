@@ -0,0 +1,109 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Command errgen generates sentinel errors, typed error constructors and catalog of error codes
+// from YAML or JSON error spec file. Usage with go generate:
+//
+//	//go:generate go run github.com/crypto-bundle/bc-wallet-common-lib-errors/cmd/errgen -spec errors.yaml -out errors_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+const generatedFilePerm = 0o600
+
+func main() {
+	var (
+		specPath        = flag.String("spec", "", "path to YAML or JSON error spec file")
+		outPath         = flag.String("out", "errors_gen.go", "path to generated Go file")
+		markdownPath    = flag.String("catalog-md", "", "path to generated markdown catalog, optional")
+		jsonCatalogPath = flag.String("catalog-json", "", "path to generated JSON catalog, optional")
+	)
+
+	flag.Parse()
+
+	err := run(*specPath, *outPath, *markdownPath, *jsonCatalogPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "errgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, outPath, markdownPath, jsonCatalogPath string) error {
+	if specPath == "" {
+		return fmt.Errorf("%w: -spec flag is required", errInvalidSpec)
+	}
+
+	spec, err := readSpec(specPath)
+	if err != nil {
+		return err
+	}
+
+	err = spec.validate()
+	if err != nil {
+		return err
+	}
+
+	source, err := generateGo(spec)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(outPath, source, generatedFilePerm)
+	if err != nil {
+		return fmt.Errorf("unable to write generated code: %w", err)
+	}
+
+	if markdownPath != "" {
+		err = os.WriteFile(markdownPath, generateMarkdownCatalog(spec), generatedFilePerm)
+		if err != nil {
+			return fmt.Errorf("unable to write markdown catalog: %w", err)
+		}
+	}
+
+	if jsonCatalogPath != "" {
+		catalog, catalogErr := generateJSONCatalog(spec)
+		if catalogErr != nil {
+			return catalogErr
+		}
+
+		err = os.WriteFile(jsonCatalogPath, catalog, generatedFilePerm)
+		if err != nil {
+			return fmt.Errorf("unable to write json catalog: %w", err)
+		}
+	}
+
+	return nil
+}
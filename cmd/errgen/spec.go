@@ -0,0 +1,303 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	errInvalidSpec = errors.New("invalid spec")
+
+	placeholderRegexp = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+)
+
+// Spec - error spec file, describes scopes and errors of package...
+type Spec struct {
+	Package string      `json:"package" yaml:"package"`
+	Scopes  []ScopeSpec `json:"scopes"  yaml:"scopes"`
+}
+
+// ScopeSpec - scope of errors...
+type ScopeSpec struct {
+	// Name - scope value of errors, e.g. wallet_signer
+	Name string `json:"name"    yaml:"name"`
+	// GoName - part of Go identifiers of scope, by default Name in camel case
+	GoName string      `json:"go_name" yaml:"go_name"`
+	Errors []ErrorSpec `json:"errors"  yaml:"errors"`
+}
+
+// ErrorSpec - error with code, public code and message template...
+type ErrorSpec struct {
+	// Name - part of Go identifiers of error, e.g. KeyNotFound
+	Name        string `json:"name"        yaml:"name"`
+	Code        int    `json:"code"        yaml:"code"`
+	PublicCode  int    `json:"public_code" yaml:"public_code"`
+	HTTPStatus  int    `json:"http_status" yaml:"http_status"`
+	GRPCCode    uint32 `json:"grpc_code"   yaml:"grpc_code"`
+	Severity    string `json:"severity"    yaml:"severity"`
+	Description string `json:"description" yaml:"description"`
+	// Text - text of sentinel error, by default Name in lower case words
+	Text string `json:"text"    yaml:"text"`
+	// Message - template of error details with {param} placeholders
+	Message string      `json:"message" yaml:"message"`
+	Params  []ParamSpec `json:"params"  yaml:"params"`
+}
+
+// ParamSpec - typed param of error constructor...
+type ParamSpec struct {
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type" yaml:"type"`
+}
+
+//nolint:gochecknoglobals // it's ok - names of imports of generated code, params must not shadow them
+var reservedParamNames = map[string]struct{}{
+	"errors":       {},
+	"fmt":          {},
+	"errformatter": {},
+}
+
+//nolint:gochecknoglobals // it's ok - map of severity names to constants
+var severityConstants = map[string]string{
+	"":         "errformatter.SeverityUnknown",
+	"debug":    "errformatter.SeverityDebug",
+	"info":     "errformatter.SeverityInfo",
+	"warning":  "errformatter.SeverityWarning",
+	"error":    "errformatter.SeverityError",
+	"critical": "errformatter.SeverityCritical",
+}
+
+func readSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read spec file: %w", err)
+	}
+
+	var spec Spec
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &spec)
+	} else {
+		err = yaml.Unmarshal(data, &spec)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode spec file: %w", err)
+	}
+
+	return &spec, nil
+}
+
+//nolint:cyclop // it's ok - validation of all spec fields
+func (s *Spec) validate() error {
+	if !token.IsIdentifier(s.Package) {
+		return fmt.Errorf("%w: package name %q is not valid identifier", errInvalidSpec, s.Package)
+	}
+
+	codes := make(map[int]string)
+	names := make(map[string]struct{})
+	scopeGoNames := make(map[string]string)
+
+	for i := range s.Scopes {
+		scope := &s.Scopes[i]
+
+		if scope.GoName == "" {
+			scope.GoName = camelCase(scope.Name)
+		}
+
+		if scope.Name == "" || !token.IsIdentifier(scope.GoName) {
+			return fmt.Errorf("%w: scope %q has invalid name", errInvalidSpec, scope.Name)
+		}
+
+		if name, isExists := scopeGoNames[scope.GoName]; isExists {
+			return fmt.Errorf("%w: duplicate go name %s of scopes %q and %q",
+				errInvalidSpec, scope.GoName, name, scope.Name)
+		}
+
+		scopeGoNames[scope.GoName] = scope.Name
+
+		for j := range scope.Errors {
+			errSpec := &scope.Errors[j]
+
+			if !token.IsIdentifier(errSpec.Name) || !token.IsExported(errSpec.Name) {
+				return fmt.Errorf("%w: error name %q is not exported identifier", errInvalidSpec, errSpec.Name)
+			}
+
+			if _, isExists := names[errSpec.Name]; isExists {
+				return fmt.Errorf("%w: duplicate error name %s", errInvalidSpec, errSpec.Name)
+			}
+
+			if errSpec.Code <= 0 {
+				return fmt.Errorf("%w: code of error %s must be positive value", errInvalidSpec, errSpec.Name)
+			}
+
+			if name, isExists := codes[errSpec.Code]; isExists {
+				return fmt.Errorf("%w: duplicate code %d of errors %s and %s",
+					errInvalidSpec, errSpec.Code, name, errSpec.Name)
+			}
+
+			if _, isExists := severityConstants[errSpec.Severity]; !isExists {
+				return fmt.Errorf("%w: unknown severity %q of error %s", errInvalidSpec, errSpec.Severity, errSpec.Name)
+			}
+
+			if errSpec.Text == "" {
+				errSpec.Text = lowerWords(errSpec.Name)
+			}
+
+			_, err := errSpec.format()
+			if err != nil {
+				return err
+			}
+
+			names[errSpec.Name] = struct{}{}
+			codes[errSpec.Code] = errSpec.Name
+		}
+	}
+
+	return nil
+}
+
+// format converts message template to printf format string and list of args in order of placeholders...
+func (e *ErrorSpec) format() (messageFormat, error) {
+	params := make(map[string]string, len(e.Params))
+
+	for i := range e.Params {
+		if !token.IsIdentifier(e.Params[i].Name) || e.Params[i].Type == "" {
+			return messageFormat{}, fmt.Errorf("%w: invalid param %q of error %s",
+				errInvalidSpec, e.Params[i].Name, e.Name)
+		}
+
+		if _, isReserved := reservedParamNames[e.Params[i].Name]; isReserved {
+			return messageFormat{}, fmt.Errorf("%w: param %q of error %s shadows import of generated code",
+				errInvalidSpec, e.Params[i].Name, e.Name)
+		}
+
+		params[e.Params[i].Name] = e.Params[i].Type
+	}
+
+	var (
+		result messageFormat
+		err    error
+	)
+
+	result.Format = placeholderRegexp.ReplaceAllStringFunc(strings.ReplaceAll(e.Message, "%", "%%"),
+		func(placeholder string) string {
+			name := placeholder[1 : len(placeholder)-1]
+
+			paramType, isExists := params[name]
+			if !isExists {
+				err = fmt.Errorf("%w: placeholder %s of error %s is not declared in params",
+					errInvalidSpec, placeholder, e.Name)
+
+				return placeholder
+			}
+
+			result.Args = append(result.Args, name)
+
+			return verbOf(paramType)
+		})
+
+	return result, err
+}
+
+type messageFormat struct {
+	Format string
+	Args   []string
+}
+
+func verbOf(paramType string) string {
+	switch paramType {
+	case "string":
+		return "%s"
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "%d"
+	case "float32", "float64":
+		return "%g"
+	case "bool":
+		return "%t"
+	default:
+		return "%v"
+	}
+}
+
+func camelCase(name string) string {
+	var builder strings.Builder
+
+	isUpperNext := true
+
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			isUpperNext = true
+
+			continue
+		}
+
+		if isUpperNext {
+			r = unicode.ToUpper(r)
+			isUpperNext = false
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// lowerWords splits camel case name to lower case words, acronyms are kept as one word...
+func lowerWords(name string) string {
+	var builder strings.Builder
+
+	runes := []rune(name)
+
+	for i, r := range runes {
+		isWordStart := i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+		if isWordStart {
+			builder.WriteRune(' ')
+		}
+
+		builder.WriteRune(unicode.ToLower(r))
+	}
+
+	return builder.String()
+}
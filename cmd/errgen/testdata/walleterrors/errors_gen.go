@@ -0,0 +1,80 @@
+// Code generated by errgen. DO NOT EDIT.
+
+package walleterrors
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+// Scopes of errors.
+const (
+	ScopeWalletSigner = "wallet_signer"
+)
+
+// Codes and public codes of errors.
+const (
+	CodeKeyNotFound       = 404
+	PublicCodeKeyNotFound = 1042
+	CodeHSMUnreachable    = 503
+)
+
+// Sentinel errors. Errors of typed constructors wrap sentinel errors.
+var (
+	ErrKeyNotFound    = errors.New("key not found")
+	ErrHSMUnreachable = errors.New("hsm unreachable")
+)
+
+// Catalog of error codes. Formatters of typed constructors take public codes and severities of errors
+// from catalog, catalog can be passed to WithCatalog options of httperr and grpcerr packages.
+var Catalog = errformatter.NewCatalog().MustRegister(
+	errformatter.CodeInfo{
+		Code:        CodeKeyNotFound,
+		Name:        "KeyNotFound",
+		Description: "Private key of wallet not found in storage",
+		PublicCode:  1042,
+		HTTPStatus:  404,
+		GRPCCode:    5,
+		Severity:    errformatter.SeverityWarning,
+	},
+	errformatter.CodeInfo{
+		Code:        CodeHSMUnreachable,
+		Name:        "HSMUnreachable",
+		Description: "HSM is unreachable",
+		PublicCode:  0,
+		HTTPStatus:  503,
+		GRPCCode:    14,
+		Severity:    errformatter.SeverityCritical,
+	},
+)
+
+var (
+	errFmtKeyNotFound = errformatter.New(
+		errformatter.WithValues(
+			errformatter.NewValue(errformatter.KindScope, ScopeWalletSigner),
+			errformatter.NewValue(errformatter.KindCode, CodeKeyNotFound),
+		),
+		errformatter.WithCatalog(Catalog),
+	)
+	errFmtHSMUnreachable = errformatter.New(
+		errformatter.WithValues(
+			errformatter.NewValue(errformatter.KindScope, ScopeWalletSigner),
+			errformatter.NewValue(errformatter.KindCode, CodeHSMUnreachable),
+		),
+		errformatter.WithCatalog(Catalog),
+	)
+)
+
+// NewKeyNotFoundError returns error with scope wallet_signer and code 404.
+// Private key of wallet not found in storage
+func NewKeyNotFoundError(keyID string, chainID int) error {
+	return errFmtKeyNotFound.Error(ErrKeyNotFound, fmt.Sprintf("key %s not found for chain %d", keyID, chainID))
+}
+
+// NewHSMUnreachableError returns error with scope wallet_signer and code 503.
+// HSM is unreachable
+func NewHSMUnreachableError() error {
+	return errFmtHSMUnreachable.Error(ErrHSMUnreachable)
+}
@@ -0,0 +1,497 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Package errfmtcheck provides analyzer, which checks usage conventions of errformatter package:
+// registration of codes in catalog, usage of formatter services instead of fmt.Errorf,
+// ErrorNoWrap calls and count of args of format strings...
+package errfmtcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	// ErrFormatterPkgPath - import path of errformatter package...
+	ErrFormatterPkgPath = "github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+
+	analyzerName = "errfmtcheck"
+	analyzerDoc  = `check usage conventions of errformatter package
+
+The errfmtcheck analyzer reports:
+  - ErrorWithCode/ErrWithCode calls with constant codes, which are not registered in catalog
+  - fmt.Errorf calls in functions, which have access to formatter service
+  - ErrorNoWrap calls with errors, which are already wrapped by formatter or fmt.Errorf
+  - mismatch of verbs count of format string and args count of Errorf/NewErrorf calls
+
+Catalog codes are collected from errformatter.CodeInfo literals of analyzed package and its dependencies.
+Additional codes can be passed by -codes flag, e.g. -codes=404,500.`
+)
+
+//nolint:gochecknoglobals // it's ok - analyzer must be package-level variable for analysis drivers
+var Analyzer = &analysis.Analyzer{
+	Name:      analyzerName,
+	Doc:       analyzerDoc,
+	URL:       "https://" + strings.TrimPrefix(ErrFormatterPkgPath, "github.com/"),
+	Run:       run,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{(*catalogCodes)(nil)},
+}
+
+//nolint:gochecknoglobals // it's ok - value of -codes flag of analyzer
+var extraCodes string
+
+func init() {
+	Analyzer.Flags.StringVar(&extraCodes, "codes", "",
+		"comma-separated list of codes, which are registered in catalog in addition to CodeInfo literals")
+}
+
+// catalogCodes - fact of package with codes of errformatter.CodeInfo literals...
+type catalogCodes struct {
+	Codes []int
+}
+
+func (*catalogCodes) AFact() {}
+
+func (f *catalogCodes) String() string {
+	codes := make([]string, len(f.Codes))
+	for i := range f.Codes {
+		codes[i] = strconv.Itoa(f.Codes[i])
+	}
+
+	return "catalogCodes(" + strings.Join(codes, ",") + ")"
+}
+
+// wrapMethods - methods of formatter services and functions of errformatter package, which wrap errors...
+//
+//nolint:gochecknoglobals // it's ok - read-only set of method names
+var wrapMethods = map[string]bool{
+	"Error":               true,
+	"ErrorOnly":           true,
+	"Errorf":              true,
+	"ErrorCtx":            true,
+	"ErrorfCtx":           true,
+	"ErrorWithCode":       true,
+	"ErrWithCode":         true,
+	"ErrorWithPublicCode": true,
+	"ErrWithPublicCode":   true,
+	"ErrorWithRetry":      true,
+	"ErrWithRetry":        true,
+	"ErrorWithRetryAfter": true,
+	"ErrWithRetryAfter":   true,
+	"ErrorWithSeverity":   true,
+	"ErrWithSeverity":     true,
+	"ValuedError":         true,
+	"ValuedErrorf":        true,
+	"ValuedErrorOnly":     true,
+	"ScopedError":         true,
+	"ScopedErrorf":        true,
+	"ScopedErrorOnly":     true,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect, _ := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	codes := collectCatalogCodes(pass, inspect)
+
+	checker := &checker{
+		pass:      pass,
+		codes:     codes,
+		isWrapped: make(map[types.Object]bool),
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(node ast.Node, isPush bool, stack []ast.Node) bool {
+		if !isPush {
+			return true
+		}
+
+		switch typedNode := node.(type) {
+		case *ast.AssignStmt:
+			checker.trackAssign(typedNode.Lhs, typedNode.Rhs)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(typedNode.Names))
+			for i := range typedNode.Names {
+				lhs[i] = typedNode.Names[i]
+			}
+
+			checker.trackAssign(lhs, typedNode.Values)
+		case *ast.CallExpr:
+			checker.checkCall(typedNode, stack)
+		}
+
+		return true
+	})
+
+	return nil, nil //nolint:nilnil // it's ok - analyzer has no result
+}
+
+// collectCatalogCodes returns codes of CodeInfo literals of package, dependencies and -codes flag.
+// Codes of package are exported as fact for dependent packages...
+func collectCatalogCodes(pass *analysis.Pass, inspect *inspector.Inspector) map[int]bool {
+	ownCodes := make([]int, 0)
+
+	inspect.Preorder([]ast.Node{(*ast.CompositeLit)(nil)}, func(node ast.Node) {
+		literal, _ := node.(*ast.CompositeLit)
+		if !isNamedType(pass.TypesInfo.TypeOf(literal), ErrFormatterPkgPath, "CodeInfo") {
+			return
+		}
+
+		for _, element := range literal.Elts {
+			keyValue, isKeyValue := element.(*ast.KeyValueExpr)
+			if !isKeyValue {
+				continue
+			}
+
+			if key, isIdent := keyValue.Key.(*ast.Ident); !isIdent || key.Name != "Code" {
+				continue
+			}
+
+			if code, isConst := constInt(pass, keyValue.Value); isConst {
+				ownCodes = append(ownCodes, code)
+			}
+		}
+	})
+
+	codes := make(map[int]bool)
+
+	for i := range ownCodes {
+		codes[ownCodes[i]] = true
+	}
+
+	if len(ownCodes) > 0 {
+		sort.Ints(ownCodes)
+		pass.ExportPackageFact(&catalogCodes{Codes: ownCodes})
+	}
+
+	for _, fact := range pass.AllPackageFacts() {
+		if packageCodes, isCodes := fact.Fact.(*catalogCodes); isCodes {
+			for i := range packageCodes.Codes {
+				codes[packageCodes.Codes[i]] = true
+			}
+		}
+	}
+
+	for _, rawCode := range strings.Split(extraCodes, ",") {
+		if code, err := strconv.Atoi(strings.TrimSpace(rawCode)); err == nil {
+			codes[code] = true
+		}
+	}
+
+	return codes
+}
+
+type checker struct {
+	pass *analysis.Pass
+	// codes - codes of catalog, check of codes is disabled if list is empty
+	codes map[int]bool
+	// isWrapped - variables, which are assigned by result of wrap call, in source order
+	isWrapped map[types.Object]bool
+}
+
+func (c *checker) trackAssign(lhs []ast.Expr, rhs []ast.Expr) {
+	if len(lhs) != len(rhs) {
+		return
+	}
+
+	for i := range lhs {
+		ident, isIdent := lhs[i].(*ast.Ident)
+		if !isIdent {
+			continue
+		}
+
+		obj := c.pass.TypesInfo.ObjectOf(ident)
+		if obj == nil {
+			continue
+		}
+
+		call, isCall := ast.Unparen(rhs[i]).(*ast.CallExpr)
+		c.isWrapped[obj] = isCall && c.isWrapCall(call)
+	}
+}
+
+func (c *checker) checkCall(call *ast.CallExpr, stack []ast.Node) {
+	fn, isFunc := typeutil.Callee(c.pass.TypesInfo, call).(*types.Func)
+	if !isFunc {
+		return
+	}
+
+	if fn.Pkg() != nil && fn.Pkg().Path() == "fmt" && fn.Name() == "Errorf" {
+		c.checkFmtErrorf(call, stack)
+
+		return
+	}
+
+	if !c.isErrFormatterFunc(fn) {
+		return
+	}
+
+	switch fn.Name() {
+	case "ErrorWithCode", "ErrWithCode":
+		c.checkCode(call)
+	case "ErrorNoWrap", "ErrNoWrap":
+		c.checkNoWrap(call, fn)
+	}
+
+	c.checkFormat(call, fn)
+}
+
+// checkCode reports constant codes, which are not registered in catalog...
+func (c *checker) checkCode(call *ast.CallExpr) {
+	const codeArgIndex = 1
+
+	if len(c.codes) == 0 || len(call.Args) <= codeArgIndex {
+		return
+	}
+
+	code, isConst := constInt(c.pass, call.Args[codeArgIndex])
+	if !isConst || c.codes[code] {
+		return
+	}
+
+	c.pass.Reportf(call.Args[codeArgIndex].Pos(), "code %d is not registered in catalog", code)
+}
+
+// checkNoWrap reports ErrorNoWrap calls with errors, which are already wrapped...
+func (c *checker) checkNoWrap(call *ast.CallExpr, fn *types.Func) {
+	if len(call.Args) != 1 {
+		return
+	}
+
+	var isWrapped bool
+
+	switch arg := ast.Unparen(call.Args[0]).(type) {
+	case *ast.CallExpr:
+		isWrapped = c.isWrapCall(arg)
+	case *ast.Ident:
+		isWrapped = c.isWrapped[c.pass.TypesInfo.ObjectOf(arg)]
+	}
+
+	if isWrapped {
+		c.pass.Reportf(call.Pos(), "%s is called with already wrapped error", fn.Name())
+	}
+}
+
+// checkFormat reports mismatch of verbs count of constant format string and args count...
+func (c *checker) checkFormat(call *ast.CallExpr, fn *types.Func) {
+	sig, _ := fn.Type().(*types.Signature)
+	if sig == nil || !sig.Variadic() || call.Ellipsis.IsValid() {
+		return
+	}
+
+	formatIndex := -1
+
+	for i := range sig.Params().Len() - 1 {
+		param := sig.Params().At(i)
+		if param.Name() == "format" && types.Identical(param.Type(), types.Typ[types.String]) {
+			formatIndex = i
+
+			break
+		}
+	}
+
+	if formatIndex < 0 || len(call.Args) <= formatIndex {
+		return
+	}
+
+	formatValue := c.pass.TypesInfo.Types[call.Args[formatIndex]].Value
+	if formatValue == nil || formatValue.Kind() != constant.String {
+		return
+	}
+
+	verbsCount, isCountable := countVerbs(constant.StringVal(formatValue))
+	if !isCountable {
+		return
+	}
+
+	argsCount := len(call.Args) - (sig.Params().Len() - 1)
+	if verbsCount != argsCount {
+		c.pass.Reportf(call.Args[formatIndex].Pos(),
+			"format string of %s has %d verbs, but %d args are given", fn.Name(), verbsCount, argsCount)
+	}
+}
+
+// checkFmtErrorf reports fmt.Errorf calls in functions, which have access to formatter service...
+func (c *checker) checkFmtErrorf(call *ast.CallExpr, stack []ast.Node) {
+	scope := c.innermostScope(call, stack)
+
+	for ; scope != nil && scope != types.Universe; scope = scope.Parent() {
+		for _, name := range scope.Names() {
+			variable, isVar := scope.Lookup(name).(*types.Var)
+			if !isVar || (variable.Pos() > call.Pos() && scope != c.pass.Pkg.Scope()) {
+				continue
+			}
+
+			if isFormatterType(variable.Type()) {
+				c.pass.Reportf(call.Pos(), "fmt.Errorf is used, but formatter service %s is in scope", name)
+
+				return
+			}
+		}
+	}
+
+	if field, isFound := c.receiverFormatterField(stack); isFound {
+		c.pass.Reportf(call.Pos(), "fmt.Errorf is used, but formatter service %s is in scope", field)
+	}
+}
+
+func (c *checker) innermostScope(call *ast.CallExpr, stack []ast.Node) *types.Scope {
+	for i := len(stack) - 1; i >= 0; i-- {
+		var funcType *ast.FuncType
+
+		switch typedNode := stack[i].(type) {
+		case *ast.FuncDecl:
+			funcType = typedNode.Type
+		case *ast.FuncLit:
+			funcType = typedNode.Type
+		default:
+			continue
+		}
+
+		if scope := c.pass.TypesInfo.Scopes[funcType]; scope != nil {
+			return scope.Innermost(call.Pos())
+		}
+	}
+
+	return c.pass.Pkg.Scope()
+}
+
+// receiverFormatterField returns name of formatter field of receiver of method, e.g. s.errFmtSvc...
+func (c *checker) receiverFormatterField(stack []ast.Node) (string, bool) {
+	for i := range stack {
+		funcDecl, isFuncDecl := stack[i].(*ast.FuncDecl)
+		if !isFuncDecl || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+			continue
+		}
+
+		recvType := c.pass.TypesInfo.TypeOf(funcDecl.Recv.List[0].Type)
+		if pointer, isPointer := recvType.(*types.Pointer); isPointer {
+			recvType = pointer.Elem()
+		}
+
+		structType, isStruct := recvType.Underlying().(*types.Struct)
+		if !isStruct {
+			return "", false
+		}
+
+		for j := range structType.NumFields() {
+			if isFormatterType(structType.Field(j).Type()) {
+				return structType.Field(j).Name(), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// isWrapCall reports whether call wraps error - fmt.Errorf or wrap method of formatter or errformatter function...
+func (c *checker) isWrapCall(call *ast.CallExpr) bool {
+	fn, isFunc := typeutil.Callee(c.pass.TypesInfo, call).(*types.Func)
+	if !isFunc {
+		return false
+	}
+
+	if fn.Pkg() != nil && fn.Pkg().Path() == "fmt" && fn.Name() == "Errorf" {
+		return true
+	}
+
+	return c.isErrFormatterFunc(fn) && wrapMethods[fn.Name()]
+}
+
+// isErrFormatterFunc reports whether function is method of formatter service or function of errformatter package...
+func (c *checker) isErrFormatterFunc(fn *types.Func) bool {
+	sig, _ := fn.Type().(*types.Signature)
+	if sig == nil {
+		return false
+	}
+
+	if sig.Recv() != nil {
+		return isFormatterType(sig.Recv().Type())
+	}
+
+	return fn.Pkg() != nil && fn.Pkg().Path() == ErrFormatterPkgPath
+}
+
+// isFormatterType reports whether type has methods of formatter services - ErrorWithCode, Errorf and NewErrorf...
+func isFormatterType(typ types.Type) bool {
+	if _, isPointer := typ.(*types.Pointer); !isPointer && !types.IsInterface(typ) {
+		typ = types.NewPointer(typ)
+	}
+
+	methodSet := types.NewMethodSet(typ)
+
+	for _, name := range []string{"ErrorWithCode", "Errorf", "NewErrorf"} {
+		if methodSet.Lookup(nil, name) == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isNamedType(typ types.Type, pkgPath string, name string) bool {
+	named, isNamed := typ.(*types.Named)
+	if !isNamed {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
+
+func constInt(pass *analysis.Pass, expr ast.Expr) (int, bool) {
+	value := pass.TypesInfo.Types[expr].Value
+	if value == nil || value.Kind() != constant.Int {
+		return 0, false
+	}
+
+	code, isExact := constant.Int64Val(value)
+
+	return int(code), isExact
+}
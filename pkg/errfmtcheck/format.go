@@ -0,0 +1,91 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errfmtcheck
+
+import "strings"
+
+const formatFlags = "+-# 0"
+
+// countVerbs returns count of args, which are used by format string. Returns false if format string
+// uses explicit argument indexes or is malformed...
+func countVerbs(format string) (int, bool) {
+	count := 0
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		i++
+		if i < len(format) && format[i] == '%' {
+			continue
+		}
+
+		for i < len(format) && strings.IndexByte(formatFlags, format[i]) >= 0 {
+			i++
+		}
+
+		next, starsCount, isCountable := skipWidthAndPrecision(format, i)
+		if !isCountable || next >= len(format) {
+			return 0, false
+		}
+
+		// stars of width and precision and verb itself consume args
+		count += starsCount + 1
+		i = next
+	}
+
+	return count, true
+}
+
+// skipWidthAndPrecision returns position of verb and count of stars of width and precision.
+// Returns false if explicit argument index is used...
+func skipWidthAndPrecision(format string, i int) (int, int, bool) {
+	starsCount := 0
+
+	for isPrecision := false; i < len(format); i++ {
+		switch {
+		case format[i] == '[':
+			return i, 0, false
+		case format[i] == '*':
+			starsCount++
+		case format[i] >= '0' && format[i] <= '9':
+		case format[i] == '.' && !isPrecision:
+			isPrecision = true
+		default:
+			return i, starsCount, true
+		}
+	}
+
+	return i, starsCount, true
+}
@@ -0,0 +1,92 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+
+	"walletcatalog"
+)
+
+type errFormatterService interface {
+	ErrorWithCode(err error, code int) error
+	ErrorNoWrap(err error) error
+	Errorf(err error, format string, args ...interface{}) error
+	NewErrorf(format string, args ...interface{}) error
+}
+
+type signer struct {
+	e errFormatterService
+}
+
+var errKeyNotFound = errors.New("key not found")
+
+func (s *signer) sign() error {
+	_ = s.e.ErrorWithCode(errKeyNotFound, walletcatalog.CodeWalletNotFound)
+	_ = s.e.ErrorWithCode(errKeyNotFound, 100500) // want `code 100500 is not registered in catalog`
+
+	return fmt.Errorf("unable to sign: %w", errKeyNotFound) // want `fmt.Errorf is used, but formatter service e is in scope`
+}
+
+func (s *signer) noWrap() error {
+	wrappedErr := s.e.Errorf(errKeyNotFound, "key %s", "id")
+	_ = s.e.ErrorNoWrap(wrappedErr)                                      // want `ErrorNoWrap is called with already wrapped error`
+	_ = errformatter.ErrorNoWrap(fmt.Errorf("wrap: %w", errKeyNotFound)) // want `ErrorNoWrap is called with already wrapped error` `fmt.Errorf is used, but formatter service e is in scope`
+
+	return s.e.ErrorNoWrap(errKeyNotFound)
+}
+
+func (s *signer) format() error {
+	_ = s.e.Errorf(errKeyNotFound, "key %s of chain %d", "id") // want `format string of Errorf has 2 verbs, but 1 args are given`
+	_ = s.e.NewErrorf("%*d%% done", 3, 50)
+	_ = errformatter.NewScopedErrorf("key %s", "scope") // want `format string of NewScopedErrorf has 1 verbs, but 0 args are given`
+
+	args := []interface{}{"id"}
+
+	return s.e.NewErrorf("key %s of chain %d", args...)
+}
+
+func plain() error {
+	return fmt.Errorf("plain: %w", errKeyNotFound)
+}
+
+func local() error {
+	svc := errformatter.NewErrorFormatter()
+
+	_ = svc.ErrWithCode(errKeyNotFound, 500)
+
+	return fmt.Errorf("local: %w", errKeyNotFound) // want `fmt.Errorf is used, but formatter service svc is in scope`
+}
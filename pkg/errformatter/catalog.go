@@ -0,0 +1,166 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrInvalidCatalogCode - returned by Catalog.Register in case of not positive code or empty name...
+	ErrInvalidCatalogCode = errors.New("errfmt: invalid catalog code")
+	// ErrDuplicateCatalogCode - returned by Catalog.Register in case of already registered code or name...
+	ErrDuplicateCatalogCode = errors.New("errfmt: duplicate catalog code")
+)
+
+// CodeInfo - human-readable metadata of error code...
+type CodeInfo struct {
+	Code        int
+	Name        string
+	Description string
+	// PublicCode - default public code of errors with Code
+	PublicCode int
+	// HTTPStatus - default HTTP status of errors with Code
+	HTTPStatus int
+	// GRPCCode - default gRPC status code of errors with Code, value of google.golang.org/grpc/codes.Code type
+	GRPCCode uint32
+	Severity Severity
+}
+
+// Catalog - registry of error codes. Guards code collisions across services.
+// Zero value of Catalog is empty catalog ready to use...
+type Catalog struct {
+	mu sync.RWMutex
+
+	codes map[int]CodeInfo
+	names map[string]int
+}
+
+// Register adds code to catalog. Returns error in case of invalid or duplicate code or name...
+func (c *Catalog) Register(info CodeInfo) error {
+	if info.Code <= 0 || info.Name == "" {
+		return fmt.Errorf("%w: code %d, name %q", ErrInvalidCatalogCode, info.Code, info.Name)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.codes == nil {
+		c.codes = make(map[int]CodeInfo)
+		c.names = make(map[string]int)
+	}
+
+	if registered, isExists := c.codes[info.Code]; isExists {
+		return fmt.Errorf("%w: code %d already registered with name %s",
+			ErrDuplicateCatalogCode, info.Code, registered.Name)
+	}
+
+	if code, isExists := c.names[info.Name]; isExists {
+		return fmt.Errorf("%w: name %s already registered with code %d",
+			ErrDuplicateCatalogCode, info.Name, code)
+	}
+
+	c.codes[info.Code] = info
+	c.names[info.Name] = info.Code
+
+	return nil
+}
+
+// MustRegister adds codes to catalog, panics in case of invalid or duplicate code. Must be used at init time...
+func (c *Catalog) MustRegister(infos ...CodeInfo) *Catalog {
+	for i := range infos {
+		err := c.Register(infos[i])
+		if err != nil {
+			panic(err.Error())
+		}
+	}
+
+	return c
+}
+
+// Lookup returns metadata of code...
+func (c *Catalog) Lookup(code int) (CodeInfo, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	info, isExists := c.codes[code]
+
+	return info, isExists
+}
+
+// LookupByName returns metadata of code by code name...
+func (c *Catalog) LookupByName(name string) (CodeInfo, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	code, isExists := c.names[name]
+	if !isExists {
+		return CodeInfo{}, false //nolint:exhaustruct // it's ok - empty info
+	}
+
+	return c.codes[code], true
+}
+
+// ErrorInfo returns metadata of code of given error...
+func (c *Catalog) ErrorInfo(err error) (CodeInfo, bool) {
+	return c.Lookup(ValuedErrorGetCode(err))
+}
+
+// Codes returns metadata of all registered codes, sorted by code...
+func (c *Catalog) Codes() []CodeInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]CodeInfo, 0, len(c.codes))
+	for _, info := range c.codes {
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+
+	return result
+}
+
+// NewCatalog returns new empty catalog of error codes...
+func NewCatalog() *Catalog {
+	return &Catalog{
+		mu:    sync.RWMutex{},
+		codes: make(map[int]CodeInfo),
+		names: make(map[string]int),
+	}
+}
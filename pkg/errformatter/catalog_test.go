@@ -0,0 +1,167 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestCatalog() *Catalog {
+	return NewCatalog().MustRegister(
+		CodeInfo{
+			Code:        404,
+			Name:        "wallet_not_found",
+			Description: "Wallet not found in storage",
+			PublicCode:  1042,
+			HTTPStatus:  404,
+			GRPCCode:    5,
+			Severity:    SeverityWarning,
+		},
+		CodeInfo{
+			Code:        500,
+			Name:        "hsm_unreachable",
+			Description: "HSM is unreachable",
+			PublicCode:  0,
+			HTTPStatus:  503,
+			GRPCCode:    14,
+			Severity:    SeverityCritical,
+		},
+	)
+}
+
+func TestCatalog(t *testing.T) {
+	t.Run("catalog - duplicate and invalid codes", func(t *testing.T) {
+		catalog := newTestCatalog()
+
+		err := catalog.Register(CodeInfo{Code: 404, Name: "another_name"})
+		if !errors.Is(err, ErrDuplicateCatalogCode) {
+			t.Errorf("error must wrap ErrDuplicateCatalogCode. current: %v", err)
+		}
+
+		err = catalog.Register(CodeInfo{Code: 405, Name: "wallet_not_found"})
+		if !errors.Is(err, ErrDuplicateCatalogCode) {
+			t.Errorf("error must wrap ErrDuplicateCatalogCode. current: %v", err)
+		}
+
+		err = catalog.Register(CodeInfo{Code: 0, Name: "zero_code"})
+		if !errors.Is(err, ErrInvalidCatalogCode) {
+			t.Errorf("error must wrap ErrInvalidCatalogCode. current: %v", err)
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Errorf("duplicate code registration must panic")
+			}
+		}()
+
+		catalog.MustRegister(CodeInfo{Code: 500, Name: "duplicate"})
+	})
+
+	t.Run("zero value catalog - register and lookup", func(t *testing.T) {
+		var catalog Catalog
+
+		if _, isExists := catalog.Lookup(404); isExists {
+			t.Errorf("code must not exist in empty catalog")
+		}
+
+		err := catalog.Register(CodeInfo{Code: 404, Name: "wallet_not_found"})
+		if err != nil {
+			t.Fatalf("unexpected register error: %s", err)
+		}
+
+		if info, isExists := catalog.LookupByName("wallet_not_found"); !isExists || info.Code != 404 {
+			t.Errorf("code info not equal with expected. current: %+v", info)
+		}
+	})
+
+	t.Run("catalog - error info by error code", func(t *testing.T) {
+		catalog := newTestCatalog()
+
+		err := NewErrorFormatter().ErrorWithCode(errors.New("test error"), 404)
+
+		info, isExists := catalog.ErrorInfo(err)
+		if !isExists || info.Name != "wallet_not_found" || info.Severity != SeverityWarning {
+			t.Errorf("error info not equal with expected. current: %+v", info)
+		}
+
+		if _, isExists = catalog.ErrorInfo(errors.New("test error")); isExists {
+			t.Errorf("error info must not exist for error without code")
+		}
+
+		if codes := catalog.Codes(); len(codes) != 2 || codes[0].Code != 404 || codes[1].Code != 500 {
+			t.Errorf("catalog codes not equal with expected. current: %+v", codes)
+		}
+	})
+
+	t.Run("service with catalog - default public code and not registered code", func(t *testing.T) {
+		const expectedPublicCode = 1042
+
+		svc := NewValuesErrorFormatterWithOptions([]Value{
+			NewValue(KindScope, "valued_err_scope"),
+		}, WithCatalog(newTestCatalog()))
+
+		err := svc.ErrorWithCode(errors.New("test error"), 404)
+
+		value, isExists := ValuedErrorGetValue(err, KindPublicCode)
+		if !isExists || value.GetPublicCode() != expectedPublicCode {
+			t.Errorf("public code not equal with expected. current: %d, expected: %d",
+				value.GetPublicCode(), expectedPublicCode)
+		}
+
+		err = NewScopedErrorFormatter("scope", WithCatalog(newTestCatalog())).
+			ErrorWithCode(errors.New("test error"), 100500)
+		if code := ValuedErrorGetCode(err); code != 100500 {
+			t.Errorf("code not equal with expected. current: %d, expected: %d", code, 100500)
+		}
+
+		if publicCode := ValuedErrorGetPublicCode(err); publicCode != ValueCodeMissing {
+			t.Errorf("public code not equal with expected. current: %d, expected: %d",
+				publicCode, ValueCodeMissing)
+		}
+	})
+
+	t.Run("valued service with catalog - public code and severity of default code", func(t *testing.T) {
+		svc := New(WithValues(NewValue(KindScope, "valued_err_scope"), NewValue(KindCode, 404)),
+			WithCatalog(newTestCatalog()))
+
+		err := svc.NewError("detail_1")
+		if publicCode := ValuedErrorGetPublicCode(err); publicCode != 1042 {
+			t.Errorf("public code not equal with expected. current: %d, expected: %d", publicCode, 1042)
+		}
+
+		if severity := ErrorSeverity(err); severity != SeverityWarning {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s", severity, SeverityWarning)
+		}
+	})
+}
@@ -32,12 +32,32 @@
 
 package errformatter
 
+import (
+	"context"
+	"time"
+)
+
+// Formatter - common interface of formatter services in plain, scoped and valued modes, see New function...
+//
 //nolint:interfacebloat //it's ok here, we need it we must use it as one big interface
-type selfService interface {
+type Formatter interface {
 	ErrorWithCode(err error, code int) error
 	ErrWithCode(err error, code int) error
 	ErrorGetCode(err error) int
 	ErrGetCode(err error) int
+	ErrorWithPublicCode(err error, publicCode int) error
+	ErrWithPublicCode(err error, publicCode int) error
+	ErrorGetPublicCode(err error) int
+	ErrGetPublicCode(err error) int
+	// ErrorWithRetry sets retry classification of error - retryable or non-retryable...
+	ErrorWithRetry(err error, isRetryable bool) error
+	ErrWithRetry(err error, isRetryable bool) error
+	// ErrorWithRetryAfter sets retryable classification of error with min delay before retry...
+	ErrorWithRetryAfter(err error, after time.Duration) error
+	ErrWithRetryAfter(err error, after time.Duration) error
+	// ErrorWithSeverity sets severity of error...
+	ErrorWithSeverity(err error, severity Severity) error
+	ErrWithSeverity(err error, severity Severity) error
 	// ErrorNoWrap function for pseudo-wrap error, must be used in case of linter warnings...
 	ErrorNoWrap(err error) error
 	// ErrNoWrap same with ErrorNoWrap function, just alias for ErrorNoWrap, just short function name...
@@ -47,4 +67,42 @@ type selfService interface {
 	Errorf(err error, format string, args ...interface{}) error
 	NewError(details ...string) error
 	NewErrorf(format string, args ...interface{}) error
+	// ErrorCtx same with Error, but error also contains values extracted from context by ContextValues function...
+	ErrorCtx(ctx context.Context, err error, details ...string) error
+	// ErrorfCtx same with Errorf, but error also contains values extracted from context...
+	ErrorfCtx(ctx context.Context, err error, format string, args ...interface{}) error
+	// NewErrorCtx same with NewError, but error also contains values extracted from context...
+	NewErrorCtx(ctx context.Context, details ...string) error
+	// NewErrorfCtx same with NewErrorf, but error also contains values extracted from context...
+	NewErrorfCtx(ctx context.Context, format string, args ...interface{}) error
+	// WithScope returns child formatter service, scope of child service is scope path of current service
+	// scope and given scope, e.g. wallet/signer/ecdsa...
+	WithScope(scope string) Formatter
+}
+
+// New returns formatter service. Mode of formatter depends on options:
+//   - valued mode, if WithValues option is set - all errors are valued errors with given values and scope
+//   - scoped mode, if only WithScope option is set - errors text starts with scope
+//   - plain mode otherwise...
+func New(opts ...Option) Formatter {
+	options := newOptions(opts...)
+
+	switch {
+	case options.isValued:
+		values := options.values
+		if options.scope != "" {
+			values = append([]Value{NewValue(KindScope, options.scope)}, values...)
+		}
+
+		return newValuesErrorFormatter(values, options)
+	case options.scope != "":
+		return &serviceScoped{
+			scope:   options.scope,
+			options: options,
+		}
+	default:
+		return &service{
+			options: options,
+		}
+	}
 }
@@ -0,0 +1,104 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("new - mode of formatter depends on options", func(t *testing.T) {
+		testCases := map[string]struct {
+			formatter      Formatter
+			expectedResult string
+			expectedCode   int
+		}{
+			"plain": {
+				formatter:      New(),
+				expectedResult: "test error -> detail_1",
+				expectedCode:   ValueCodeMissing,
+			},
+			"scoped": {
+				formatter:      New(WithScope("wallet")),
+				expectedResult: "wallet: test error -> detail_1",
+				expectedCode:   ValueCodeMissing,
+			},
+			"valued": {
+				formatter:      New(WithValues(NewValue(KindCode, 404))),
+				expectedResult: "test error -> detail_1",
+				expectedCode:   404,
+			},
+			"valued with scope": {
+				formatter:      New(WithScope("wallet"), WithValues(NewValue(KindCode, 404))),
+				expectedResult: "wallet: test error -> detail_1",
+				expectedCode:   404,
+			},
+		}
+
+		for name, testCase := range testCases {
+			err := testCase.formatter.Error(errors.New("test error"), "detail_1")
+
+			if err.Error() != testCase.expectedResult {
+				t.Errorf("%s: error text not equal with expected. current: %s, expected: %s",
+					name, err.Error(), testCase.expectedResult)
+			}
+
+			if code := ValuedErrorGetCode(err); code != testCase.expectedCode {
+				t.Errorf("%s: error code not equal with expected. current: %d, expected: %d",
+					name, code, testCase.expectedCode)
+			}
+		}
+	})
+
+	t.Run("new - old constructors are adapters of New", func(t *testing.T) {
+		if _, isPlain := NewErrorFormatter().(*service); !isPlain {
+			t.Errorf("formatter must be in plain mode")
+		}
+
+		if svc, isScoped := NewScopedErrorFormatter("wallet", WithScope("other")).(*serviceScoped); !isScoped ||
+			svc.scope != "wallet" {
+			t.Errorf("formatter must be in scoped mode with scope of constructor argument")
+		}
+
+		if _, isValued := NewValuesErrorFormatter().(*serviceValued); !isValued {
+			t.Errorf("formatter must be in valued mode")
+		}
+
+		svc, isValued := NewValuesErrorFormatterWithOptions([]Value{NewValue(KindCode, 404)},
+			WithSeverity(SeverityWarning)).(*serviceValuedWithDefaults)
+		if !isValued || len(svc.defaultValues) != 2 {
+			t.Errorf("formatter must be in valued mode with default values")
+		}
+	})
+}
@@ -35,4 +35,8 @@ package errformatter
 const (
 	CallerStackSkip  = 2
 	ValueCodeMissing = -1
+	// MaxStackDepth - max count of frames in captured stack trace...
+	MaxStackDepth = 32
+	// ScopeSeparator - separator of segments of hierarchical scope path, e.g. wallet/signer/ecdsa...
+	ScopeSeparator = "/"
 )
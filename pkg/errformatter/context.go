@@ -0,0 +1,108 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// ContextExtractor - function, which returns values stored in context, e.g. request ID, trace ID or tenant...
+type ContextExtractor func(ctx context.Context) []Value
+
+type contextExtractorRegistry struct {
+	mu sync.RWMutex
+
+	extractors []ContextExtractor
+}
+
+//nolint:gochecknoglobals // it's ok - registry of context extractors must be shared by all formatters
+var contextExtractors = &contextExtractorRegistry{
+	mu:         sync.RWMutex{},
+	extractors: make([]ContextExtractor, 0),
+}
+
+func (r *contextExtractorRegistry) register(extractor ContextExtractor) {
+	if extractor == nil {
+		panic("errfmt: context extractor must be not nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.extractors = append(r.extractors, extractor)
+}
+
+func (r *contextExtractorRegistry) extract(ctx context.Context) []Value {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var values []Value
+
+	for i := range r.extractors {
+		values = append(values, r.extractors[i](ctx)...)
+	}
+
+	return values
+}
+
+// contextValuesKey - key of values, stored in context by ContextWithValues function...
+type contextValuesKey struct{}
+
+// RegisterContextExtractor registers extractor of values from context. Values of all registered extractors
+// are attached to errors, created by ErrorCtx, ErrorfCtx, NewErrorCtx and NewErrorfCtx receiver-methods...
+func RegisterContextExtractor(extractor ContextExtractor) {
+	contextExtractors.register(extractor)
+}
+
+// ContextWithValues returns copy of context with given values added to values of parent context...
+func ContextWithValues(ctx context.Context, values ...Value) context.Context {
+	stored, _ := ctx.Value(contextValuesKey{}).([]Value)
+
+	return context.WithValue(ctx, contextValuesKey{}, append(slices.Clip(stored), values...))
+}
+
+// ContextValues returns values of registered context extractors and values stored by ContextWithValues function.
+// Values stored by ContextWithValues function overwrite values of extractors with same kind...
+func ContextValues(ctx context.Context) []Value {
+	if ctx == nil {
+		return nil
+	}
+
+	values := contextExtractors.extract(ctx)
+
+	stored, _ := ctx.Value(contextValuesKey{}).([]Value)
+
+	return append(values, stored...)
+}
@@ -0,0 +1,152 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type testTenantContextKey struct{}
+
+//nolint:gochecknoglobals // it's ok - custom kinds must be registered once for all tests
+var testKindTenant = RegisterKind("test_tenant", WithKindType[string]())
+
+//nolint:gochecknoinits // it's ok - context extractors must be registered once for all tests
+func init() {
+	RegisterContextExtractor(func(ctx context.Context) []Value {
+		tenant, isExists := ctx.Value(testTenantContextKey{}).(string)
+		if !isExists {
+			return nil
+		}
+
+		return []Value{NewValue(testKindTenant, tenant)}
+	})
+}
+
+func TestContextValues(t *testing.T) {
+	t.Run("valued formatter - values of context extractors and context values", func(t *testing.T) {
+		const (
+			expectedResult = "ctx_scope: test error -> detail_1"
+			expectedTenant = "tenant_1"
+			expectedCode   = 1003
+		)
+
+		ctx := context.WithValue(context.Background(), testTenantContextKey{}, expectedTenant)
+		ctx = ContextWithValues(ctx, NewValue(KindCode, expectedCode))
+
+		svc := NewValuesErrorFormatter(NewValue(KindScope, "ctx_scope"))
+
+		err := svc.ErrorCtx(ctx, errors.New("test error"), "detail_1")
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		if tenant, _ := ValuedErrorGet[string](err, testKindTenant); tenant != expectedTenant {
+			t.Errorf("tenant value not equal with expected. current: %s, expected: %s",
+				tenant, expectedTenant)
+		}
+
+		if code := ValuedErrorGetCode(err); code != expectedCode {
+			t.Errorf("error code not equal with expected. current: %d, expected: %d",
+				code, expectedCode)
+		}
+	})
+
+	t.Run("scoped formatter - context values attached to new error", func(t *testing.T) {
+		const (
+			expectedResult = "ctx_scope: test error 42"
+			expectedTenant = "tenant_2"
+		)
+
+		ctx := context.WithValue(context.Background(), testTenantContextKey{}, expectedTenant)
+
+		err := NewScopedErrorFormatter("ctx_scope").NewErrorfCtx(ctx, "test error %d", 42)
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		if tenant, _ := ValuedErrorGet[string](err, testKindTenant); tenant != expectedTenant {
+			t.Errorf("tenant value not equal with expected. current: %s, expected: %s",
+				tenant, expectedTenant)
+		}
+	})
+
+	t.Run("formatter - context without values", func(t *testing.T) {
+		const expectedResult = "test error -> detail_1"
+
+		err := NewErrorFormatter().ErrorCtx(context.Background(), errors.New("test error"), "detail_1")
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		if _, isExists := ValuedErrorGetValue(err, testKindTenant); isExists {
+			t.Errorf("tenant value must not exist in error without context values")
+		}
+	})
+
+	t.Run("context with values - nil error is not wrapped", func(t *testing.T) {
+		ctx := context.WithValue(ContextWithValues(context.Background(), NewValue(KindPublicCode, 7)),
+			testTenantContextKey{}, "tenant_1")
+
+		services := map[string]Formatter{
+			"plain":  NewErrorFormatter(),
+			"scoped": NewScopedErrorFormatter("scope"),
+			"valued": NewValuesErrorFormatter(NewValue(KindScope, "scope")),
+		}
+
+		for name, svc := range services {
+			if err := svc.ErrorCtx(ctx, nil, "detail_1"); err != nil {
+				t.Errorf("%s: error must be nil. current: %#v", name, err)
+			}
+
+			if err := svc.ErrorfCtx(ctx, nil, "detail_%d", 1); err != nil {
+				t.Errorf("%s: error must be nil. current: %#v", name, err)
+			}
+		}
+	})
+
+	t.Run("context with values - parent context values are not changed", func(t *testing.T) {
+		parent := ContextWithValues(context.Background(), NewValue(KindCode, 1))
+		_ = ContextWithValues(parent, NewValue(KindPublicCode, 2))
+
+		if values := ContextValues(parent); len(values) != 1 {
+			t.Errorf("count of context values not equal with expected. current: %d, expected: %d",
+				len(values), 1)
+		}
+	})
+}
@@ -33,11 +33,42 @@
 package errformatter
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 	"strings"
 )
 
+type formattedError struct {
+	Err     error
+	details []string
+}
+
+// Error to string converter...
+func (e formattedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns previous error...
+func (e formattedError) Unwrap() error {
+	return errors.Unwrap(e.Err)
+}
+
+// Format implements fmt.Formatter interface, %+v renders multi-line report with details and causes...
+func (e formattedError) Format(state fmt.State, verb rune) {
+	formatError(state, verb, e, e.verboseReport)
+}
+
+func (e *formattedError) verboseReport() string {
+	var report verboseReport
+
+	report.writeText(e)
+	report.writeList("details", e.details)
+	report.writeCauses(e.Unwrap())
+
+	return report.String()
+}
+
 func ErrorNoWrap(err error) error {
 	if err == nil {
 		return nil
@@ -48,6 +79,10 @@ func ErrorNoWrap(err error) error {
 
 // ErrorOnly combines given error with details, WITHOUT function name...
 func ErrorOnly(err error, details ...string) error {
+	return formattedErrorOnly(err, nil, details...)
+}
+
+func formattedErrorOnly(err error, layout *Layout, details ...string) error {
 	if err == nil {
 		return nil
 	}
@@ -56,7 +91,17 @@ func ErrorOnly(err error, details ...string) error {
 		return err
 	}
 
-	return fmt.Errorf("%w -> %s", err, strings.Join(details, ", "))
+	details = redactDetails(details)
+
+	return &formattedError{
+		Err: resolveLayout(layout).wrap(err, LayoutData{
+			Scope:   "",
+			Code:    0,
+			Cause:   "",
+			Details: details,
+		}),
+		details: details,
+	}
 }
 
 // Error combines given error with details and finishes with caller func name...
@@ -68,17 +113,19 @@ func Error(err error, details ...string) error {
 //
 //nolint:err113
 func NewError(details ...string) error {
-	return fmt.Errorf("%s", strings.Join(details, ", "))
+	return newError(nil, details...)
+}
+
+//nolint:err113
+func newError(layout *Layout, details ...string) error {
+	return errors.New(resolveLayout(layout).joinDetails(redactDetails(details)))
 }
 
 // NewErrorf returns error by combining given details and finishes with caller func name, printf formatting...
 //
 //nolint:err113
 func NewErrorf(format string, args ...interface{}) error {
-	return fmt.Errorf(
-		"%s",
-		strings.Join([]string{fmt.Sprintf(format, args...)}, ", "),
-	)
+	return newError(nil, fmt.Sprintf(format, args...))
 }
 
 // Errorf combines given error with details and finishes with caller func name, printf formatting...
@@ -35,15 +35,15 @@ package errformatter
 import (
 	"errors"
 	"fmt"
-	"strings"
 )
 
 // ErrorScoped its type just for backward compatibility...
 type ErrorScoped scopedError
 
 type scopedError struct {
-	Err   error
-	scope string
+	Err     error
+	scope   string
+	details []string
 }
 
 // Error to string converter...
@@ -56,22 +56,46 @@ func (e scopedError) Unwrap() error {
 	return errors.Unwrap(e.Err)
 }
 
+// Format implements fmt.Formatter interface, %+v renders multi-line report with scope, details and causes...
+func (e scopedError) Format(state fmt.State, verb rune) {
+	formatError(state, verb, e, e.verboseReport)
+}
+
+func (e *scopedError) verboseReport() string {
+	var report verboseReport
+
+	report.writeText(e)
+	report.writeField("scope", e.scope)
+	report.writeList("details", e.details)
+	report.writeCauses(e.Unwrap())
+
+	return report.String()
+}
+
 // ScopedErrorOnly combines given error with details, WITHOUT function name...
 func ScopedErrorOnly(err error, scope string, details ...string) *scopedError {
+	return scopedErrorOnly(err, nil, scope, details...)
+}
+
+func scopedErrorOnly(err error, layout *Layout, scope string, details ...string) *scopedError {
 	if err == nil {
 		return nil
 	}
 
+	details = redactDetails(details)
 	if len(details) == 0 {
-		return &scopedError{
-			scope: scope,
-			Err:   fmt.Errorf("%s: %w", scope, err),
-		}
+		details = nil
 	}
 
 	return &scopedError{
-		scope: scope,
-		Err:   fmt.Errorf("%s: %w -> %s", scope, err, strings.Join(details, ", ")),
+		scope:   scope,
+		details: details,
+		Err: resolveLayout(layout).wrap(err, LayoutData{
+			Scope:   scope,
+			Code:    0,
+			Cause:   "",
+			Details: details,
+		}),
 	}
 }
 
@@ -81,27 +105,29 @@ func ScopedError(err error, scope string, details ...string) *scopedError {
 }
 
 // NewScopedError returns error by combining given details and finishes with caller func name...
-//
-//nolint:err113
 func NewScopedError(scope string, details ...string) *scopedError {
+	return newScopedError(nil, scope, details...)
+}
+
+//nolint:err113
+func newScopedError(layout *Layout, scope string, details ...string) *scopedError {
+	resolvedLayout := resolveLayout(layout)
+
 	return &scopedError{
-		Err: fmt.Errorf("%s: %s", scope,
-			strings.Join(details, ", ")),
-		scope: scope,
+		Err: errors.New(resolvedLayout.render(LayoutData{
+			Scope:   scope,
+			Code:    0,
+			Cause:   resolvedLayout.joinDetails(redactDetails(details)),
+			Details: nil,
+		})),
+		scope:   scope,
+		details: nil,
 	}
 }
 
 // NewScopedErrorf returns error by combining given details and finishes with caller func name, printf formatting...
-//
-//nolint:err113
 func NewScopedErrorf(format string, scope string, args ...interface{}) *scopedError {
-	return &scopedError{
-		Err: fmt.Errorf(
-			"%s: %s", scope,
-			strings.Join([]string{fmt.Sprintf(format, args...)}, ", "),
-		),
-		scope: scope,
-	}
+	return newScopedError(nil, scope, fmt.Sprintf(format, args...))
 }
 
 // ScopedErrorf combines given error with details and finishes with caller func name, printf formatting...
@@ -35,13 +35,30 @@ package errformatter
 import (
 	"errors"
 	"fmt"
-	"strings"
+	"slices"
+	"strconv"
 )
 
+// valuedError is immutable after creation - all wrap operations return new error node,
+// which references previous one. So valued errors are safe to share between goroutines...
 type valuedError struct {
-	Err     error
-	values  [MaxKindValue]Value
-	settled Bits
+	Err    error
+	values [MaxKindValue + 1]Value
+	// custom - values of custom kinds, registered by RegisterKind function
+	custom []Value
+	// stack - stack trace of error origin, captured only if stack capture is enabled
+	stack Stack
+	// previous - error node, which was wrapped to current node by one of wrap operations
+	previous *valuedError
+	// scopePath - scope path of error origin, kept on re-wrap by formatters with other scopes
+	scopePath string
+	// layout - layout of error text, package-level default layout is used if layout is nil
+	layout *Layout
+	// renderedCode - code, which is already rendered in error text, code is not rendered twice on re-wrap
+	renderedCode int
+	// isSentinel - error is defined by Define function, errors with same scope and code match it by errors.Is
+	isSentinel bool
+	settled    Bits
 }
 
 // Error to string converter...
@@ -54,25 +71,146 @@ func (e valuedError) Unwrap() error {
 	return errors.Unwrap(e.Err)
 }
 
+// Format implements fmt.Formatter interface, %+v renders multi-line report with values, causes and stack...
+func (e valuedError) Format(state fmt.State, verb rune) {
+	formatError(state, verb, e, e.verboseReport)
+}
+
+func (e *valuedError) verboseReport() string {
+	var report verboseReport
+
+	report.writeText(e)
+
+	if e.settled.Has(ValueScopeIsSet) {
+		report.writeField("scope", e.values[KindScope].getScope())
+	}
+
+	if e.settled.Has(ValueCodeIsSet) {
+		report.writeField("code", strconv.Itoa(e.values[KindCode].getCode()))
+	}
+
+	if e.settled.Has(ValuePublicCodeIsSet) {
+		report.writeField("public_code", strconv.Itoa(e.values[KindPublicCode].getPublicCode()))
+	}
+
+	if e.settled.Has(ValueRetryIsSet) {
+		report.writeField("retry", e.values[KindRetry].getRetry().String())
+	}
+
+	if e.settled.Has(ValueSeverityIsSet) {
+		report.writeField("severity", e.values[KindSeverity].getSeverity().String())
+	}
+
+	for i := range e.custom {
+		report.writeField(e.custom[i].num.String(), fmt.Sprint(e.custom[i].any))
+	}
+
+	if e.settled.Has(ValueDetailsIsSet) {
+		report.writeList("details", e.values[KindDetails].getDetails())
+	}
+
+	report.writeCauses(e.Unwrap())
+	report.writeStack(e.stack)
+
+	return report.String()
+}
+
+// StackTrace returns stack trace captured at place of error origin...
+func (e *valuedError) StackTrace() Stack {
+	return e.stack
+}
+
+// setStack sets stack trace only if error has no stack yet - deepest origin frame set is always kept...
+func (e *valuedError) setStack(stack Stack) *valuedError {
+	if e.stack == nil {
+		e.stack = stack
+	}
+
+	return e
+}
+
+// Is reports whether target is one of previous nodes of error, so errors.Is works with shared valued errors.
+// Sentinel target, see Define function, is matched by scope and code of error or any previous node...
+func (e *valuedError) Is(target error) bool {
+	//nolint:errorlint // it's ok - here we need to compare nodes identity
+	targetErr, isValued := target.(*valuedError)
+	if !isValued {
+		return false
+	}
+
+	if targetErr.isSentinel && e.matchSentinel(targetErr) {
+		return true
+	}
+
+	for previous := e.previous; previous != nil; previous = previous.previous {
+		if previous == targetErr {
+			return true
+		}
+
+		if targetErr.isSentinel && previous.matchSentinel(targetErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clone returns copy of error node, which references current node as previous...
+func (e *valuedError) clone() *valuedError {
+	next := *e
+	next.custom = slices.Clone(e.custom)
+	next.previous = e
+	next.isSentinel = false
+
+	if e.settled.Has(ValueDetailsIsSet) {
+		next.values[KindDetails].any = slices.Clone(e.values[KindDetails].getDetails())
+	}
+
+	return &next
+}
+
+// SetScope returns new error node with given scope, current error is not changed...
 func (e *valuedError) SetScope(scope string) *valuedError {
+	return e.clone().setScope(scope)
+}
+
+func (e *valuedError) setScope(scope string) *valuedError {
 	e.settled.Set(KindScope.Bits())
-	e.values[KindScope].SetScope(scope)
+	e.values[KindScope].setScope(scope)
+
+	return e.setScopePath(scope)
+}
+
+// setScopePath sets scope path only if error has no scope path yet - scope path of error origin is always kept...
+func (e *valuedError) setScopePath(scope string) *valuedError {
+	if e.scopePath == "" {
+		e.scopePath = scope
+	}
 
 	return e
 }
 
+// MergeDetails returns new error node with merged details, current error is not changed...
 func (e *valuedError) MergeDetails(details ...string) *valuedError {
+	return e.clone().mergeDetails(details...)
+}
+
+func (e *valuedError) mergeDetails(details ...string) *valuedError {
 	e.settled.Set(KindDetails.Bits())
+	e.values[KindDetails].num = KindDetails
 	e.values[KindDetails].mergeDetails(details...)
 
 	return e
 }
 
+// AddDetails returns new error node with added details, current error is not changed...
 func (e *valuedError) AddDetails(details ...string) *valuedError {
-	e.settled.Set(KindDetails.Bits())
-	e.values[KindDetails].addDetails(details...)
+	next := e.clone()
+	next.settled.Set(KindDetails.Bits())
+	next.values[KindDetails].num = KindDetails
+	next.values[KindDetails].addDetails(details...)
 
-	return e
+	return next
 }
 
 func (e *valuedError) ScopeIs(scope string) bool {
@@ -89,6 +227,14 @@ func (e *valuedError) scopeIsEqualWith(scope string) bool {
 	return currentScope == scope
 }
 
+func (e *valuedError) getScope() string {
+	if !e.settled.Has(ValueScopeIsSet) {
+		return ""
+	}
+
+	return e.values[KindScope].getScope()
+}
+
 func (e *valuedError) getCode() int {
 	if !e.settled.Has(ValueCodeIsSet) {
 		return ValueCodeMissing
@@ -97,6 +243,14 @@ func (e *valuedError) getCode() int {
 	return e.values[KindCode].getCode()
 }
 
+func (e *valuedError) getPublicCode() int {
+	if !e.settled.Has(ValuePublicCodeIsSet) {
+		return ValueCodeMissing
+	}
+
+	return e.values[KindPublicCode].getPublicCode()
+}
+
 func (e *valuedError) setValues(values ...Value) *valuedError {
 	for i := range values {
 		_ = e.setValue(values[i])
@@ -106,52 +260,130 @@ func (e *valuedError) setValues(values ...Value) *valuedError {
 }
 
 func (e *valuedError) setValue(value Value) *valuedError {
+	if value.num.isCustom() {
+		return e.setCustomValue(value)
+	}
+
 	e.values[value.num] = value
 	e.settled.Set(value.num.Bits())
 
+	if value.num == KindScope {
+		return e.setScopePath(value.getScope())
+	}
+
 	return e
 }
 
-func (e *valuedError) setError(err error) *valuedError {
-	switch {
-	case e.settled.Has(ValueDetailsIsSet) && e.settled.Has(ValueScopeIsSet):
-		scope := e.values[KindScope].getScope()
-		details := e.values[KindDetails].getDetails()
+func (e *valuedError) setCustomValue(value Value) *valuedError {
+	e.settled.Set(value.num.Bits())
 
-		e.Err = fmt.Errorf("%s: %w -> %s", scope, err, strings.Join(details, ", "))
+	for i := range e.custom {
+		if e.custom[i].num == value.num {
+			e.custom[i] = value
 
-	case e.settled.Has(ValueDetailsIsSet) && !e.settled.Has(ValueScopeIsSet):
-		details := e.values[KindDetails].getDetails()
+			return e
+		}
+	}
 
-		e.Err = fmt.Errorf("%w -> %s", err, strings.Join(details, ", "))
+	e.custom = append(e.custom, value)
 
-	case !e.settled.Has(ValueDetailsIsSet) && e.settled.Has(ValueScopeIsSet):
-		scope := e.values[KindScope].getScope()
+	return e
+}
 
-		e.Err = fmt.Errorf("%s: %w", scope, err)
-	default:
-		e.Err = fmt.Errorf("%w", err)
+func (e *valuedError) getValue(kind Kind) (Value, bool) {
+	bits := kind.Bits()
+	if bits == 0 || !e.settled.Has(bits) {
+		//nolint:exhaustruct //it's ok - field _ disallow struct comparison
+		return Value{}, false
+	}
+
+	if !kind.isCustom() {
+		return e.values[kind], true
+	}
+
+	for i := range e.custom {
+		if e.custom[i].num == kind {
+			return e.custom[i], true
+		}
+	}
+
+	//nolint:exhaustruct //it's ok - field _ disallow struct comparison
+	return Value{}, false
+}
+
+// setLayout sets layout of error text, error keeps own layout if given layout is nil...
+func (e *valuedError) setLayout(layout *Layout) *valuedError {
+	if layout != nil {
+		e.layout = layout
 	}
 
 	return e
 }
 
+func (e *valuedError) setError(err error) *valuedError {
+	return e.wrapError(err, true, true)
+}
+
+// wrapError wraps given error by text of error values. Scope is not rendered on re-wrap without new scope and
+// details are not rendered on re-wrap without new details, because text of wrapped error already contains them...
+func (e *valuedError) wrapError(err error, isScopeRendered, isDetailsRendered bool) *valuedError {
+	data := LayoutData{
+		Scope:   "",
+		Code:    0,
+		Cause:   "",
+		Details: nil,
+	}
+
+	if isScopeRendered && e.settled.Has(ValueScopeIsSet) {
+		data.Scope = e.values[KindScope].getScope()
+	}
+
+	if isDetailsRendered && e.settled.Has(ValueDetailsIsSet) {
+		data.Details = redactDetails(e.values[KindDetails].getDetails())
+	}
+
+	if code := e.getCode(); code > 0 && code != e.renderedCode {
+		data.Code = code
+		e.renderedCode = code
+	}
+
+	e.Err = resolveLayout(e.layout).wrap(err, data)
+
+	return e
+}
+
 func (e *valuedError) reWrap(value Value) *valuedError {
+	// values of code, public code and custom kinds are not part of error text
+	if !value.KindOf(KindDetails) && !value.KindOf(KindScope) {
+		return e.setValue(value)
+	}
+
 	if value.Kind() != KindScope {
-		return e.setValue(value).setError(e.Err)
+		return e.setValue(value).wrapError(e.Err, false, true)
 	}
 
 	if !e.scopeIsEqualWith(value.getScope()) {
-		return e.setValue(value).setError(e.Err)
+		return e.setValue(value).wrapError(e.Err, true, false)
 	}
 
 	return e
 }
 
+// hasKind reports whether values list contains value of given kind...
+func hasKind(values []Value, kind Kind) bool {
+	for i := range values {
+		if values[i].KindOf(kind) {
+			return true
+		}
+	}
+
+	return false
+}
+
 //nolint:cyclop // it's ok. this function is really need to be with not easy logic
 func (e *valuedError) reWrapByValues(values ...Value) *valuedError {
 	if !e.settled.Has(ValueScopeIsSet) {
-		return e.setValues(values...).setError(e.Err)
+		return e.setValues(values...).wrapError(e.Err, true, hasKind(values, KindDetails))
 	}
 
 	var (
@@ -181,11 +413,11 @@ func (e *valuedError) reWrapByValues(values ...Value) *valuedError {
 		return e
 	// if case of wrap error to new error with old scope and new details
 	case !isNewScopeExists && isNewDetailsExists:
-		return e.setValue(newDetailsValue).setError(e.Err)
+		return e.setValue(newDetailsValue).wrapError(e.Err, false, true)
 
 	// if case of re-format error with same scope and new details
 	case isNewScopeExists && isNewDetailsExists && e.scopeIsEqualWith(newScopeValue.getScope()):
-		return e.MergeDetails(newDetailsValue.getDetails()...).setError(e.Unwrap())
+		return e.mergeDetails(newDetailsValue.getDetails()...).setError(e.Unwrap())
 
 	// if case when we have no new details and scope is equal with current, just return current error instance
 	case isNewScopeExists && !isNewDetailsExists && e.scopeIsEqualWith(newScopeValue.getScope()):
@@ -193,7 +425,7 @@ func (e *valuedError) reWrapByValues(values ...Value) *valuedError {
 
 	// if case of wrap error to new error with new scope and without new details
 	case isNewScopeExists && !isNewDetailsExists && !e.scopeIsEqualWith(newScopeValue.getScope()):
-		return e.setValue(newScopeValue).setError(e.Err)
+		return e.setValue(newScopeValue).wrapError(e.Err, true, false)
 
 	// if case of wrap error to new error with new scope with and details
 	// set new scope value, and set new details value, and wrap current error to new
@@ -215,51 +447,130 @@ func ValuedErrorGetCode(err error) int {
 	return vErr.getCode()
 }
 
+// ValuedErrorGetPublicCode returns public code of valued error or ValueCodeMissing...
+func ValuedErrorGetPublicCode(err error) int {
+	var vErr *valuedError
+
+	if !errors.As(err, &vErr) {
+		return ValueCodeMissing
+	}
+
+	return vErr.getPublicCode()
+}
+
+// ValuedErrorGetValue returns Value of given built-in or custom Kind from valued error...
+func ValuedErrorGetValue(err error, kind Kind) (Value, bool) {
+	var vErr *valuedError
+
+	if !errors.As(err, &vErr) {
+		//nolint:exhaustruct //it's ok - field _ disallow struct comparison
+		return Value{}, false
+	}
+
+	return vErr.getValue(kind)
+}
+
+// ValuedErrorGet returns typed value of given Kind from valued error...
+func ValuedErrorGet[T any](err error, kind Kind) (T, bool) {
+	value, isExists := ValuedErrorGetValue(err, kind)
+	if !isExists {
+		var empty T
+
+		return empty, false
+	}
+
+	return ValueAs[T](value)
+}
+
+// RestoreValuedError returns valued error with given text and values, without formatting of error text.
+// Can be used for restore error, received from another process...
+func RestoreValuedError(message string, cause error, values ...Value) *valuedError {
+	vErr := &valuedError{
+		Err: &textError{
+			message: message,
+			cause:   cause,
+		},
+		values:       [MaxKindValue + 1]Value{},
+		custom:       nil,
+		stack:        nil,
+		previous:     nil,
+		scopePath:    "",
+		layout:       nil,
+		renderedCode: 0,
+		isSentinel:   false,
+		settled:      0,
+	}
+
+	return vErr.setValues(values...)
+}
+
 // ValuedErrorOnly combines given error with given Value, all Value type values must contain pre-reserved Kind...
 func ValuedErrorOnly(err error, value Value) *valuedError {
+	return observedValued(valuedErrorOnly(err, captureStack(false, 1), nil, value))
+}
+
+func valuedErrorOnly(err error, stack Stack, layout *Layout, value Value) *valuedError {
 	if err == nil {
 		return nil
 	}
 
 	var vErr *valuedError
 	if errors.As(err, &vErr) {
-		return vErr.reWrap(value)
+		return vErr.clone().setStack(stack).setLayout(layout).reWrap(value)
 	}
 
 	vErr = &valuedError{
-		Err:     nil,
-		values:  [4]Value{},
-		settled: 0,
+		Err:          nil,
+		values:       [MaxKindValue + 1]Value{},
+		custom:       nil,
+		stack:        stack,
+		previous:     nil,
+		scopePath:    "",
+		layout:       nil,
+		renderedCode: 0,
+		isSentinel:   false,
+		settled:      0,
 	}
 
-	return vErr.setValue(value).setError(err)
+	return vErr.setLayout(layout).setValue(value).setError(err)
 }
 
 // MultiValuedErrorOnly combines given error with given Value list, all Value type values must contain pre-reserved Kind...
 func MultiValuedErrorOnly(err error, value ...Value) *valuedError {
+	return observedValued(multiValuedErrorOnly(err, captureStack(false, 1), nil, value...))
+}
+
+func multiValuedErrorOnly(err error, stack Stack, layout *Layout, value ...Value) *valuedError {
 	if err == nil {
 		return nil
 	}
 
 	var vErr *valuedError
 	if errors.As(err, &vErr) {
-		return vErr.reWrapByValues(value...)
+		return vErr.clone().setStack(stack).setLayout(layout).reWrapByValues(value...)
 	}
 
 	vErr = &valuedError{
-		Err:     nil,
-		values:  [4]Value{},
-		settled: 0,
+		Err:          nil,
+		values:       [MaxKindValue + 1]Value{},
+		custom:       nil,
+		stack:        stack,
+		previous:     nil,
+		scopePath:    "",
+		layout:       nil,
+		renderedCode: 0,
+		isSentinel:   false,
+		settled:      0,
 	}
 
-	return vErr.setValues(value...).setError(err)
+	return vErr.setLayout(layout).setValues(value...).setError(err)
 }
 
 // ValuedError combines given error with details and finishes with caller func name, printf formatting...
 func ValuedError(err error, values []Value, details ...string) *valuedError {
 	values = append(values, NewValue(KindDetails, details))
 
-	return MultiValuedErrorOnly(err, values...)
+	return observedValued(multiValuedErrorOnly(err, captureStack(false, 1), nil, values...))
 }
 
 // ValuedErrorf combines given error with details and finishes with caller func name, printf formatting...
@@ -267,6 +578,16 @@ func ValuedErrorf(err error,
 	values []Value,
 	format string,
 	args ...interface{},
+) *valuedError {
+	return observedValued(valuedErrorf(err, captureStack(false, 1), nil, values, format, args...))
+}
+
+func valuedErrorf(err error,
+	stack Stack,
+	layout *Layout,
+	values []Value,
+	format string,
+	args ...interface{},
 ) *valuedError {
 	if err == nil {
 		return nil
@@ -274,40 +595,52 @@ func ValuedErrorf(err error,
 
 	var vErr *valuedError
 	if errors.As(err, &vErr) {
-		vErr.Err = ErrorOnly(vErr.Err, fmt.Sprintf(format, args...))
+		next := vErr.clone().setLayout(layout)
+		next.Err = formattedErrorOnly(next.Err, next.layout, fmt.Sprintf(format, args...))
 
-		return vErr.setValues(values...)
+		return next.setStack(stack).setValues(values...)
 	}
 
 	vErr = &valuedError{
-		Err:     ErrorOnly(err, fmt.Sprintf(format, args...)),
-		values:  [4]Value{},
-		settled: 0,
+		Err:          formattedErrorOnly(err, layout, fmt.Sprintf(format, args...)),
+		values:       [MaxKindValue + 1]Value{},
+		custom:       nil,
+		stack:        stack,
+		previous:     nil,
+		scopePath:    "",
+		layout:       nil,
+		renderedCode: 0,
+		isSentinel:   false,
+		settled:      0,
 	}
 
-	return vErr.setValues(values...)
+	return vErr.setLayout(layout).setValues(values...)
 }
 
 // ValuedNewError combines given error with details and finishes with caller func name, printf formatting...
-//
-//nolint:err113
 func ValuedNewError(values []Value, details ...string) *valuedError {
+	return observedValued(valuedNewError(captureStack(false, 1), nil, values, details...))
+}
+
+//nolint:err113
+func valuedNewError(stack Stack, layout *Layout, values []Value, details ...string) *valuedError {
 	var vErr valuedError
 
-	newErr := fmt.Errorf("%s", strings.Join(details, ", "))
+	newErr := errors.New(resolveLayout(layout).joinDetails(redactDetails(details)))
 
-	return vErr.setValues(values...).setError(newErr)
+	return vErr.setStack(stack).setLayout(layout).setValues(values...).setError(newErr)
 }
 
 // ValuedNewErrorf combines given error with details and finishes with caller func name, printf formatting...
-//
-//nolint:err113
 func ValuedNewErrorf(values []Value, format string, args ...interface{}) *valuedError {
+	return observedValued(valuedNewErrorf(captureStack(false, 1), nil, values, format, args...))
+}
+
+//nolint:err113
+func valuedNewErrorf(stack Stack, layout *Layout, values []Value, format string, args ...interface{}) *valuedError {
 	var vErr valuedError
 
-	newErr := fmt.Errorf("%s",
-		strings.Join([]string{fmt.Sprintf(format, args...)}, ", "),
-	)
+	newErr := errors.New(Redact(fmt.Sprintf(format, args...)))
 
-	return vErr.setValues(values...).setError(newErr)
+	return vErr.setStack(stack).setLayout(layout).setValues(values...).setError(newErr)
 }
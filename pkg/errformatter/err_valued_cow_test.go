@@ -0,0 +1,123 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestValuedError_CopyOnWrite(t *testing.T) {
+	t.Run("valued error - concurrent re-wrap of shared error", func(t *testing.T) {
+		const (
+			expectedResult = "shared_scope: shared error -> shared_detail"
+			expectedCode   = 1001
+			wrapCount      = 64
+		)
+
+		shared := NewValuesErrorFormatter([]Value{
+			NewValue(KindScope, "shared_scope"),
+			NewValue(KindCode, expectedCode),
+			NewValue(KindDetails, []string{"shared_detail"}),
+		}...).NewError("shared error")
+
+		var (
+			wg      sync.WaitGroup
+			wrapped = make([]error, wrapCount)
+		)
+
+		for i := 0; i < wrapCount; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				svc := NewValuesErrorFormatter(NewValue(KindScope, "shared_scope"))
+				wrapped[i] = svc.ErrorWithCode(svc.ErrorOnly(shared, "detail_"+strconv.Itoa(i)), 2000+i)
+			}(i)
+		}
+
+		wg.Wait()
+
+		if shared.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				shared.Error(), expectedResult)
+		}
+
+		if code := ValuedErrorGetCode(shared); code != expectedCode {
+			t.Errorf("error code not equal with expected. current: %d, expected: %d",
+				code, expectedCode)
+		}
+
+		for i, err := range wrapped {
+			expectedWrappedResult := expectedResult + ", detail_" + strconv.Itoa(i)
+			if err.Error() != expectedWrappedResult {
+				t.Errorf("error text not equal with expected. current: %s, expected: %s",
+					err.Error(), expectedWrappedResult)
+			}
+
+			if code := ValuedErrorGetCode(err); code != 2000+i {
+				t.Errorf("error code not equal with expected. current: %d, expected: %d",
+					code, 2000+i)
+			}
+
+			if !errors.Is(err, shared) {
+				t.Errorf("wrapped error must match shared error. current: %s, expected: %s",
+					err, shared)
+			}
+		}
+	})
+
+	t.Run("valued error - copy-on-write setters", func(t *testing.T) {
+		const expectedResult = "origin_scope: origin error"
+
+		origin := ValuedNewError([]Value{NewValue(KindScope, "origin_scope")}, "origin error")
+
+		changed := origin.SetScope("changed_scope").AddDetails("changed_detail")
+		if origin.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				origin.Error(), expectedResult)
+		}
+
+		if changed == origin {
+			t.Errorf("setters must return new error node")
+		}
+
+		if !errors.Is(changed, origin) {
+			t.Errorf("changed error must match origin error. current: %s, expected: %s",
+				changed, origin)
+		}
+	})
+}
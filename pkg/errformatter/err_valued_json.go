@@ -0,0 +1,224 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidErrorJSON - returned by DecodeError function in case of invalid json data...
+var ErrInvalidErrorJSON = errors.New("errfmt: invalid error json")
+
+// valuedErrorJSON - stable json schema of valuedError...
+type valuedErrorJSON struct {
+	Message    string                     `json:"message"`
+	Scope      *string                    `json:"scope,omitempty"`
+	ScopePath  *string                    `json:"scope_path,omitempty"`
+	Code       *int                       `json:"code,omitempty"`
+	PublicCode *int                       `json:"public_code,omitempty"`
+	Retry      *Retry                     `json:"retry,omitempty"`
+	Severity   *string                    `json:"severity,omitempty"`
+	Details    []string                   `json:"details,omitempty"`
+	Values     map[string]json.RawMessage `json:"values,omitempty"`
+	Causes     []string                   `json:"causes,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler interface...
+func (e valuedError) MarshalJSON() ([]byte, error) {
+	result := valuedErrorJSON{
+		Message:    Redact(e.Error()),
+		Scope:      nil,
+		ScopePath:  nil,
+		Code:       nil,
+		PublicCode: nil,
+		Retry:      nil,
+		Severity:   nil,
+		Details:    nil,
+		Values:     nil,
+		Causes:     nil,
+	}
+
+	if e.settled.Has(ValueScopeIsSet) {
+		scope := e.values[KindScope].getScope()
+		result.Scope = &scope
+	}
+
+	// scope path of error origin is written only if it differs from current scope
+	if e.scopePath != "" && (result.Scope == nil || *result.Scope != e.scopePath) {
+		scopePath := e.scopePath
+		result.ScopePath = &scopePath
+	}
+
+	if e.settled.Has(ValueCodeIsSet) {
+		code := e.values[KindCode].getCode()
+		result.Code = &code
+	}
+
+	if e.settled.Has(ValuePublicCodeIsSet) {
+		publicCode := e.values[KindPublicCode].getPublicCode()
+		result.PublicCode = &publicCode
+	}
+
+	if e.settled.Has(ValueRetryIsSet) {
+		retry := e.values[KindRetry].getRetry()
+		result.Retry = &retry
+	}
+
+	if e.settled.Has(ValueSeverityIsSet) {
+		severity := e.values[KindSeverity].getSeverity().String()
+		result.Severity = &severity
+	}
+
+	if e.settled.Has(ValueDetailsIsSet) {
+		result.Details = redactDetails(e.values[KindDetails].getDetails())
+	}
+
+	if len(e.custom) > 0 {
+		result.Values = make(map[string]json.RawMessage, len(e.custom))
+
+		for i := range e.custom {
+			rawValue, err := json.Marshal(e.custom[i].any)
+			if err != nil {
+				return nil, fmt.Errorf("unable to marshal value of kind %s: %w", e.custom[i].num, err)
+			}
+
+			result.Values[e.custom[i].num.String()] = rawValue
+		}
+	}
+
+	for cause := e.Unwrap(); cause != nil; cause = errors.Unwrap(cause) {
+		result.Causes = append(result.Causes, Redact(cause.Error()))
+	}
+
+	//nolint:wrapcheck // it's ok - encoding errors of json package must be returned as is
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface. Values of not registered custom kinds are skipped...
+func (e *valuedError) UnmarshalJSON(data []byte) error {
+	var decoded valuedErrorJSON
+
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		//nolint:wrapcheck // it's ok - decoding errors of json package must be returned as is
+		return err
+	}
+
+	*e = valuedError{
+		Err:          nil,
+		values:       [MaxKindValue + 1]Value{},
+		custom:       nil,
+		stack:        nil,
+		previous:     nil,
+		scopePath:    "",
+		layout:       nil,
+		renderedCode: 0,
+		isSentinel:   false,
+		settled:      0,
+	}
+
+	if decoded.ScopePath != nil {
+		_ = e.setScopePath(*decoded.ScopePath)
+	}
+
+	if decoded.Scope != nil {
+		_ = e.setValue(NewValue(KindScope, *decoded.Scope))
+	}
+
+	if decoded.Code != nil {
+		_ = e.setValue(NewValue(KindCode, *decoded.Code))
+	}
+
+	if decoded.PublicCode != nil {
+		_ = e.setValue(NewValue(KindPublicCode, *decoded.PublicCode))
+	}
+
+	if decoded.Retry != nil {
+		_ = e.setValue(NewValue(KindRetry, *decoded.Retry))
+	}
+
+	if decoded.Severity != nil {
+		if severity, isKnown := ParseSeverity(*decoded.Severity); isKnown {
+			_ = e.setValue(NewSeverityValue(severity))
+		}
+	}
+
+	if decoded.Details != nil {
+		_ = e.setValue(NewValue(KindDetails, decoded.Details))
+	}
+
+	for kindName, rawValue := range decoded.Values {
+		kind, isRegistered := KindByName(kindName)
+		if !isRegistered || !kind.isCustom() {
+			continue
+		}
+
+		value, decodeErr := customKinds.decode(kind, rawValue)
+		if decodeErr != nil {
+			return fmt.Errorf("unable to unmarshal value of kind %s: %w", kindName, decodeErr)
+		}
+
+		_ = e.setValue(value)
+	}
+
+	var cause error
+
+	for i := len(decoded.Causes) - 1; i >= 0; i-- {
+		cause = &textError{
+			message: decoded.Causes[i],
+			cause:   cause,
+		}
+	}
+
+	e.Err = &textError{
+		message: decoded.Message,
+		cause:   cause,
+	}
+
+	return nil
+}
+
+// DecodeError restores valued error from json data, produced by json.Marshal of valued error.
+// Returns error which wraps ErrInvalidErrorJSON in case of invalid json data...
+func DecodeError(data []byte) error {
+	var vErr valuedError
+
+	err := json.Unmarshal(data, &vErr)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidErrorJSON, err)
+	}
+
+	return &vErr
+}
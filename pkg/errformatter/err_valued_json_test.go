@@ -0,0 +1,135 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestValuedErrorJSON(t *testing.T) {
+	t.Run("valued error - marshal to stable json schema", func(t *testing.T) {
+		const expectedResult = `{"message":"valued_err_scope: test error -\u003e detail_1, detail_2",` +
+			`"scope":"valued_err_scope","code":404,"public_code":1042,` +
+			`"details":["detail_1","detail_2"],"values":{"test_kind_chain_name":"ethereum"},` +
+			`"causes":["test error"]}`
+
+		err := MultiValuedErrorOnly(errors.New("test error"),
+			NewValue(KindScope, "valued_err_scope"),
+			NewValue(KindCode, 404),
+			NewValue(KindPublicCode, 1042),
+			NewValue(KindDetails, []string{"detail_1", "detail_2"}),
+			NewValue(testKindChainName, "ethereum"),
+		)
+
+		data, marshalErr := json.Marshal(err)
+		if marshalErr != nil {
+			t.Fatalf("unexpected marshal error: %s", marshalErr)
+		}
+
+		if string(data) != expectedResult {
+			t.Errorf("json not equal with expected. current: %s, expected: %s",
+				string(data), expectedResult)
+		}
+	})
+
+	t.Run("valued error - round-trip decoding", func(t *testing.T) {
+		const (
+			expectedResult = "valued_err_scope: test error -> cause_detail -> detail_1"
+			expectedCode   = 404
+			expectedChain  = "ethereum"
+		)
+
+		err := MultiValuedErrorOnly(ErrorOnly(errors.New("test error"), "cause_detail"),
+			NewValue(KindScope, "valued_err_scope"),
+			NewValue(KindCode, expectedCode),
+			NewValue(KindDetails, []string{"detail_1"}),
+			NewValue(testKindChainName, expectedChain),
+		)
+
+		data, marshalErr := json.Marshal(err)
+		if marshalErr != nil {
+			t.Fatalf("unexpected marshal error: %s", marshalErr)
+		}
+
+		decodedErr := DecodeError(data)
+		if decodedErr.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				decodedErr.Error(), expectedResult)
+		}
+
+		if code := ValuedErrorGetCode(decodedErr); code != expectedCode {
+			t.Errorf("error code not equal with expected. current: %d, expected: %d",
+				code, expectedCode)
+		}
+
+		var vErr *valuedError
+		if !errors.As(decodedErr, &vErr) || !vErr.ScopeIs("valued_err_scope") {
+			t.Errorf("decoded error scope not equal with expected")
+		}
+
+		if chain, _ := ValuedErrorGet[string](decodedErr, testKindChainName); chain != expectedChain {
+			t.Errorf("custom value not equal with expected. current: %s, expected: %s",
+				chain, expectedChain)
+		}
+
+		unwrappedErr := errors.Unwrap(decodedErr)
+		if unwrappedErr == nil || unwrappedErr.Error() != "test error -> cause_detail" {
+			t.Errorf("unwrapped error not equal with expected. current: %v", unwrappedErr)
+		}
+
+		if rootErr := errors.Unwrap(unwrappedErr); rootErr == nil || rootErr.Error() != "test error" {
+			t.Errorf("root error not equal with expected. current: %v", rootErr)
+		}
+	})
+
+	t.Run("decode error - value not allowed by validator of custom kind", func(t *testing.T) {
+		// json numbers are decoded to float64, so validator of int values rejects decoded value
+		err := DecodeError([]byte(`{"message":"test error","values":{"test_kind_chain_id":1}}`))
+		if !errors.Is(err, ErrInvalidErrorJSON) {
+			t.Errorf("error must wrap ErrInvalidErrorJSON. current: %v", err)
+		}
+
+		if _, isSet := ValuedErrorGetValue(err, testKindChainID); isSet {
+			t.Errorf("value of kind %s must not be decoded", testKindChainID)
+		}
+	})
+
+	t.Run("decode error - invalid json", func(t *testing.T) {
+		err := DecodeError([]byte("{invalid"))
+		if !errors.Is(err, ErrInvalidErrorJSON) {
+			t.Errorf("error must wrap ErrInvalidErrorJSON. current: %v", err)
+		}
+	})
+}
@@ -220,4 +220,50 @@ func TestValuedErrorFormatting(t *testing.T) {
 				code, ValueCodeMissing)
 		}
 	})
+
+	t.Run("re-wrap valued error by new scope without new details - details are not duplicated", func(t *testing.T) {
+		const (
+			expectedResult       = "outer_scope: test error -> detail_1"
+			expectedScopedResult = "outer_scope: inner_scope: test error -> detail_1"
+		)
+
+		err := ValuedError(errors.New("test error"), nil, "detail_1")
+
+		reWrappedErr := ValuedErrorOnly(err, NewValue(KindScope, "outer_scope"))
+		if reWrappedErr.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				reWrappedErr.Error(), expectedResult)
+		}
+
+		scopedErr := MultiValuedErrorOnly(errors.New("test error"),
+			NewValue(KindScope, "inner_scope"),
+			NewValue(KindDetails, []string{"detail_1"}),
+		)
+
+		reWrappedErr = MultiValuedErrorOnly(scopedErr, NewValue(KindScope, "outer_scope"), NewValue(KindCode, 404))
+		if reWrappedErr.Error() != expectedScopedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				reWrappedErr.Error(), expectedScopedResult)
+		}
+	})
+	t.Run("re-wrap scoped valued error by new details - scope is not duplicated", func(t *testing.T) {
+		const expectedResult = "inner_scope: test error -> detail_1"
+
+		err := MultiValuedErrorOnly(errors.New("test error"),
+			NewValue(KindScope, "inner_scope"),
+			NewValue(KindCode, 404),
+		)
+
+		reWrappedErr := ValuedErrorOnly(err, NewValue(KindDetails, []string{"detail_1"}))
+		if reWrappedErr.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				reWrappedErr.Error(), expectedResult)
+		}
+
+		reWrappedErr = ValuedError(err, nil, "detail_1")
+		if reWrappedErr.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				reWrappedErr.Error(), expectedResult)
+		}
+	})
 }
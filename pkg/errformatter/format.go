@@ -0,0 +1,149 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	verboseReportIndent     = "    "
+	verboseReportListPrefix = verboseReportIndent + "- "
+)
+
+// verboseReport - builder of multi-line error report, used for %+v formatting...
+type verboseReport struct {
+	builder strings.Builder
+}
+
+func (r *verboseReport) writeText(err error) {
+	r.builder.WriteString(Redact(err.Error()))
+	r.builder.WriteString("\n")
+}
+
+func (r *verboseReport) writeLine(line string) {
+	r.builder.WriteString(line)
+	r.builder.WriteString("\n")
+}
+
+func (r *verboseReport) writeField(name string, value string) {
+	r.builder.WriteString(name)
+	r.builder.WriteString(": ")
+	r.builder.WriteString(Redact(value))
+	r.builder.WriteString("\n")
+}
+
+func (r *verboseReport) writeList(name string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	r.builder.WriteString(name)
+	r.builder.WriteString(":\n")
+
+	for i := range items {
+		r.builder.WriteString(verboseReportListPrefix)
+		r.builder.WriteString(Redact(items[i]))
+		r.builder.WriteString("\n")
+	}
+}
+
+func (r *verboseReport) writeCauses(cause error) {
+	causes := make([]string, 0)
+
+	for cause != nil {
+		causes = append(causes, cause.Error())
+
+		if multiErr, ok := cause.(interface{ Unwrap() []error }); ok {
+			for _, joinedErr := range multiErr.Unwrap() {
+				causes = append(causes, joinedErr.Error())
+			}
+
+			break
+		}
+
+		cause = errors.Unwrap(cause)
+	}
+
+	r.writeList("causes", causes)
+}
+
+func (r *verboseReport) writeStack(stack Stack) {
+	frames := stack.Frames()
+	if len(frames) == 0 {
+		return
+	}
+
+	r.builder.WriteString("stack:\n")
+
+	for i := range frames {
+		r.builder.WriteString(verboseReportIndent)
+		r.builder.WriteString(frames[i].Function)
+		r.builder.WriteString("\n")
+		r.builder.WriteString(verboseReportIndent)
+		r.builder.WriteString("\t")
+		r.builder.WriteString(frames[i].File)
+		r.builder.WriteString(":")
+		r.builder.WriteString(strconv.Itoa(frames[i].Line))
+		r.builder.WriteString("\n")
+	}
+}
+
+func (r *verboseReport) String() string {
+	return strings.TrimSuffix(r.builder.String(), "\n")
+}
+
+// formatError - common implementation of fmt.Formatter interface for all error types of package.
+// %v and %s verbs render error text, %+v renders multi-line report...
+func formatError(state fmt.State, verb rune, err error, report func() string) {
+	switch verb {
+	case 'v':
+		if state.Flag('+') {
+			_, _ = io.WriteString(state, report())
+
+			return
+		}
+
+		_, _ = io.WriteString(state, err.Error())
+	case 's':
+		_, _ = io.WriteString(state, err.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(state, "%q", err.Error())
+	default:
+		_, _ = fmt.Fprintf(state, "%%!%c(%s)", verb, err.Error())
+	}
+}
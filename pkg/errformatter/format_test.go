@@ -0,0 +1,142 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestErrorVerboseFormatting(t *testing.T) {
+	t.Run("valued error - %v and %+v rendering", func(t *testing.T) {
+		const (
+			expectedResult  = "valued_err_scope: test error -> cause_detail -> detail_1, detail_2"
+			expectedVerbose = "valued_err_scope: test error -> cause_detail -> detail_1, detail_2\n" +
+				"scope: valued_err_scope\n" +
+				"code: 404\n" +
+				"public_code: 1042\n" +
+				"details:\n" +
+				"    - detail_1\n" +
+				"    - detail_2\n" +
+				"causes:\n" +
+				"    - test error -> cause_detail\n" +
+				"    - test error"
+		)
+
+		err := MultiValuedErrorOnly(ErrorOnly(errors.New("test error"), "cause_detail"),
+			NewValue(KindScope, "valued_err_scope"),
+			NewValue(KindCode, 404),
+			NewValue(KindPublicCode, 1042),
+			NewValue(KindDetails, []string{"detail_1", "detail_2"}),
+		)
+
+		if text := fmt.Sprintf("%v", err); text != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				text, expectedResult)
+		}
+
+		if text := fmt.Sprintf("%s", err); text != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				text, expectedResult)
+		}
+
+		if text := fmt.Sprintf("%+v", err); text != expectedVerbose {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				text, expectedVerbose)
+		}
+	})
+
+	t.Run("valued error - %+v rendering with stack", func(t *testing.T) {
+		svc := NewValuesErrorFormatterWithOptions(nil, WithStackCapture())
+
+		text := fmt.Sprintf("%+v", svc.NewError("test error"))
+		if !strings.Contains(text, "\nstack:\n") || !strings.Contains(text, "format_test.go") {
+			t.Errorf("error report must contain stack. current: %s", text)
+		}
+	})
+
+	t.Run("scoped error - %v and %+v rendering", func(t *testing.T) {
+		const (
+			expectedResult  = "test_scope: test error -> abcd, efg"
+			expectedVerbose = "test_scope: test error -> abcd, efg\n" +
+				"scope: test_scope\n" +
+				"details:\n" +
+				"    - abcd\n" +
+				"    - efg\n" +
+				"causes:\n" +
+				"    - test error"
+		)
+
+		err := ScopedError(errors.New("test error"), "test_scope", "abcd", "efg")
+
+		if text := fmt.Sprintf("%v", err); text != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				text, expectedResult)
+		}
+
+		if text := fmt.Sprintf("%+v", err); text != expectedVerbose {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				text, expectedVerbose)
+		}
+	})
+
+	t.Run("wrapped error - %v, %q and %+v rendering", func(t *testing.T) {
+		const (
+			expectedResult  = "test error -> abc"
+			expectedVerbose = "test error -> abc\n" +
+				"details:\n" +
+				"    - abc\n" +
+				"causes:\n" +
+				"    - test error"
+		)
+
+		err := ErrorOnly(errors.New("test error"), "abc")
+
+		if text := fmt.Sprintf("%v", err); text != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				text, expectedResult)
+		}
+
+		if text := fmt.Sprintf("%q", err); text != `"`+expectedResult+`"` {
+			t.Errorf("error text not equal with expected. current: %s, expected: %q",
+				text, expectedResult)
+		}
+
+		if text := fmt.Sprintf("%+v", err); text != expectedVerbose {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				text, expectedVerbose)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+const (
+	// MaxKindsCount - max count of all kinds, built-in and custom. Limited by size of Bits type...
+	MaxKindsCount = 64
+	// firstCustomKind - value of first Kind which will be returned by RegisterKind...
+	firstCustomKind = MaxKindValue + 1
+)
+
+// KindOption is optional setting of custom Kind, which can be passed to RegisterKind function...
+type KindOption func(descriptor *kindDescriptor)
+
+// WithKindValidator - sets validation function for custom Kind. NewValue panics if validator returns false...
+func WithKindValidator(validator func(value any) bool) KindOption {
+	return func(descriptor *kindDescriptor) {
+		descriptor.validator = validator
+	}
+}
+
+// WithKindType - allows only values of T type for custom Kind. Json values of kind also decoded to T type...
+func WithKindType[T any]() KindOption {
+	return func(descriptor *kindDescriptor) {
+		descriptor.validator = func(value any) bool {
+			_, ok := value.(T)
+
+			return ok
+		}
+		descriptor.decoder = func(data []byte) (any, error) {
+			var value T
+
+			err := json.Unmarshal(data, &value)
+
+			return value, err //nolint:wrapcheck // it's ok - error will be wrapped by caller
+		}
+	}
+}
+
+type kindDescriptor struct {
+	name      string
+	validator func(value any) bool
+	// decoder - decoder of json value of kind, by default json value decoded to any type
+	decoder func(data []byte) (any, error)
+}
+
+type kindRegistry struct {
+	mu sync.RWMutex
+
+	descriptors []kindDescriptor
+	names       map[string]Kind
+}
+
+//nolint:gochecknoglobals // it's ok - registry of custom kinds must be shared by all formatters
+var customKinds = &kindRegistry{
+	mu:          sync.RWMutex{},
+	descriptors: make([]kindDescriptor, 0),
+	names: map[string]Kind{
+		KinaEmptyName:      KindEmpty,
+		KindDetailsName:    KindDetails,
+		KindScopeName:      KindScope,
+		KindCodeName:       KindCode,
+		KindPublicCodeName: KindPublicCode,
+		KindRetryName:      KindRetry,
+		KindSeverityName:   KindSeverity,
+	},
+}
+
+func (r *kindRegistry) register(name string, opts ...KindOption) Kind {
+	if name == "" {
+		panic("errfmt: kind name must be not empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, isExists := r.names[name]; isExists {
+		panic(fmt.Sprintf("errfmt: kind with name %s already registered", name))
+	}
+
+	kind := firstCustomKind + Kind(len(r.descriptors))
+	if kind > MaxKindsCount {
+		panic(fmt.Sprintf("errfmt: kinds count limit exceeded, max count is %d", MaxKindsCount))
+	}
+
+	descriptor := kindDescriptor{
+		name:      name,
+		validator: nil,
+		decoder:   nil,
+	}
+
+	for i := range opts {
+		opts[i](&descriptor)
+	}
+
+	r.descriptors = append(r.descriptors, descriptor)
+	r.names[name] = kind
+
+	return kind
+}
+
+func (r *kindRegistry) descriptor(kind Kind) (kindDescriptor, bool) {
+	if kind < firstCustomKind {
+		return kindDescriptor{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	index := int(kind - firstCustomKind)
+	if index >= len(r.descriptors) {
+		return kindDescriptor{}, false
+	}
+
+	return r.descriptors[index], true
+}
+
+func (r *kindRegistry) decode(kind Kind, data []byte) (Value, error) {
+	descriptor, isRegistered := r.descriptor(kind)
+	if !isRegistered {
+		return Value{}, fmt.Errorf("errfmt: kind %d is not registered", kind) //nolint:err113,exhaustruct // it's ok
+	}
+
+	var (
+		value any
+		err   error
+	)
+
+	if descriptor.decoder != nil {
+		value, err = descriptor.decoder(data)
+	} else {
+		err = json.Unmarshal(data, &value)
+	}
+
+	if err != nil {
+		return Value{}, err //nolint:wrapcheck,exhaustruct // it's ok - error will be wrapped by caller
+	}
+
+	// decoded data can be received from another process, so validation error is returned instead of panic
+	if descriptor.validator != nil && !descriptor.validator(value) {
+		//nolint:err113,exhaustruct // it's ok - error will be wrapped by caller
+		return Value{}, fmt.Errorf("errfmt: value of type %T is not allowed for kind %s", value, descriptor.name)
+	}
+
+	//nolint:exhaustruct //it's ok - field _ disallow struct comparison
+	return Value{
+		num: kind,
+		any: value,
+	}, nil
+}
+
+func (r *kindRegistry) lookup(name string) (Kind, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kind, isExists := r.names[name]
+
+	return kind, isExists
+}
+
+// RegisterKind registers new custom Kind with given name. Must be called once per kind, e.g. in package var block.
+// Values of custom kinds are stored in valuedError next to built-in kinds values...
+func RegisterKind(name string, opts ...KindOption) Kind {
+	return customKinds.register(name, opts...)
+}
+
+// KindByName returns built-in or custom Kind by name...
+func KindByName(name string) (Kind, bool) {
+	return customKinds.lookup(name)
+}
@@ -0,0 +1,138 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"testing"
+)
+
+//nolint:gochecknoglobals // it's ok - custom kinds must be registered once
+var (
+	testKindChainName = RegisterKind("test_kind_chain_name", WithKindType[string]())
+	testKindTxHash    = RegisterKind("test_kind_tx_hash")
+	testKindChainID   = RegisterKind("test_kind_chain_id", WithKindValidator(func(value any) bool {
+		_, ok := value.(int)
+
+		return ok
+	}))
+)
+
+func TestRegisterKind(t *testing.T) {
+	t.Run("custom kind - name, bits and duplicate registration", func(t *testing.T) {
+		if testKindChainName.String() != "test_kind_chain_name" {
+			t.Errorf("kind name not equal with expected. current: %s, expected: %s",
+				testKindChainName.String(), "test_kind_chain_name")
+		}
+
+		if testKindChainName.Bits() == 0 || testKindChainName.Bits() == testKindTxHash.Bits() {
+			t.Errorf("kind bits must be unique and not empty. current: %d, %d",
+				testKindChainName.Bits(), testKindTxHash.Bits())
+		}
+
+		if kind, isExists := KindByName("test_kind_tx_hash"); !isExists || kind != testKindTxHash {
+			t.Errorf("kind not found by name. current: %d, expected: %d", kind, testKindTxHash)
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Errorf("duplicate kind registration must panic")
+			}
+		}()
+
+		_ = RegisterKind(KindScopeName)
+	})
+
+	t.Run("custom kind - value with not allowed type", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("value with not allowed type must panic")
+			}
+		}()
+
+		_ = NewValue(testKindChainName, 100500)
+	})
+
+	t.Run("valued error with custom values - typed getters", func(t *testing.T) {
+		const (
+			expectedResult = "valued_err_scope: test error"
+			expectedChain  = "ethereum"
+			expectedTxHash = "0xabcdef"
+			expectedCode   = 4
+		)
+
+		err := MultiValuedErrorOnly(errors.New("test error"),
+			NewValue(KindScope, "valued_err_scope"),
+			NewValue(testKindChainName, expectedChain),
+		)
+
+		wrappedErr := ValuedErrorOnly(err, NewValue(testKindTxHash, expectedTxHash))
+		wrappedErr = ValuedErrorOnly(wrappedErr, NewValue(KindCode, expectedCode))
+
+		if wrappedErr.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				wrappedErr.Error(), expectedResult)
+		}
+
+		if chain, _ := ValuedErrorGet[string](wrappedErr, testKindChainName); chain != expectedChain {
+			t.Errorf("custom value not equal with expected. current: %s, expected: %s",
+				chain, expectedChain)
+		}
+
+		if txHash, _ := ValuedErrorGet[string](wrappedErr, testKindTxHash); txHash != expectedTxHash {
+			t.Errorf("custom value not equal with expected. current: %s, expected: %s",
+				txHash, expectedTxHash)
+		}
+
+		if code := ValuedErrorGetCode(wrappedErr); code != expectedCode {
+			t.Errorf("error code not equal with expected. current: %d, expected: %d",
+				code, expectedCode)
+		}
+
+		if _, isExists := ValuedErrorGetValue(errors.New("test error"), testKindTxHash); isExists {
+			t.Errorf("custom value must not exist in not valued error")
+		}
+	})
+
+	t.Run("valued error with public code - built-in kind stored in array", func(t *testing.T) {
+		const expectedPublicCode = 1042
+
+		err := ValuedErrorOnly(errors.New("test error"), NewValue(KindPublicCode, expectedPublicCode))
+
+		value, isExists := ValuedErrorGetValue(err, KindPublicCode)
+		if !isExists || value.GetPublicCode() != expectedPublicCode {
+			t.Errorf("public code not equal with expected. current: %d, expected: %d",
+				value.GetPublicCode(), expectedPublicCode)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"text/template"
+)
+
+const (
+	// DefaultScopeDelimiter - default delimiter between scope and text of error...
+	DefaultScopeDelimiter = ": "
+	// DefaultDetailsDelimiter - default delimiter between text of cause and details...
+	DefaultDetailsDelimiter = " -> "
+	// DefaultDetailsJoiner - default joiner of details list...
+	DefaultDetailsJoiner = ", "
+)
+
+// Layout controls text of errors, built by formatter services and package functions.
+// Empty fields are replaced by default values, so zero Layout is layout "scope: cause -> detail, detail"...
+type Layout struct {
+	// ScopeDelimiter - delimiter between scope and text of error, ": " by default
+	ScopeDelimiter string
+	// DetailsDelimiter - delimiter between text of cause and details, " -> " by default
+	DetailsDelimiter string
+	// DetailsJoiner - joiner of details list, ", " by default
+	DetailsJoiner string
+	// CodeFormat - printf format of code prefix, e.g. "[E%d] ". Code is not rendered if format is empty
+	CodeFormat string
+	// Template - template of error text, executed with LayoutData. Other fields are ignored if template is set
+	Template *template.Template
+}
+
+// LayoutData - data of error text, passed to Layout template...
+type LayoutData struct {
+	Scope string
+	// Code - code of error, zero if code is not set
+	Code    int
+	Cause   string
+	Details []string
+}
+
+//nolint:gochecknoglobals // it's ok - package-level default layout
+var defaultLayout atomic.Pointer[Layout]
+
+// SetDefaultLayout sets layout of errors, which are built by package functions and formatter services without
+// WithLayout option...
+func SetDefaultLayout(layout Layout) {
+	defaultLayout.Store(&layout)
+}
+
+// DefaultLayout returns package-level default layout...
+func DefaultLayout() Layout {
+	layout := defaultLayout.Load()
+	if layout == nil {
+		//nolint:exhaustruct // it's ok - empty fields are replaced by default values
+		return Layout{}
+	}
+
+	return *layout
+}
+
+// NewTemplateLayout returns Layout with parsed template of error text. Template executed with LayoutData,
+// join function is available in template, e.g. {{.Scope}} | {{.Cause}} | {{join .Details "; "}}...
+func NewTemplateLayout(text string) (Layout, error) {
+	tmpl, err := template.New("errfmt").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Parse(text)
+	if err != nil {
+		//nolint:exhaustruct // it's ok - empty layout returned with error
+		return Layout{}, fmt.Errorf("unable to parse layout template: %w", err)
+	}
+
+	//nolint:exhaustruct // it's ok - empty fields are replaced by default values
+	return Layout{Template: tmpl}, nil
+}
+
+// resolveLayout returns given layout or package-level default layout if given layout is nil...
+func resolveLayout(layout *Layout) *Layout {
+	if layout != nil {
+		return layout
+	}
+
+	result := DefaultLayout()
+
+	return &result
+}
+
+func (l *Layout) joinDetails(details []string) string {
+	return strings.Join(details, valueOrDefault(l.DetailsJoiner, DefaultDetailsJoiner))
+}
+
+// render returns error text. Template execution errors are ignored - text rendered by fields of layout...
+func (l *Layout) render(data LayoutData) string {
+	if l.Template != nil {
+		var builder strings.Builder
+
+		err := l.Template.Execute(&builder, data)
+		if err == nil {
+			return builder.String()
+		}
+	}
+
+	text := data.Cause
+	if len(data.Details) > 0 {
+		text += valueOrDefault(l.DetailsDelimiter, DefaultDetailsDelimiter) + l.joinDetails(data.Details)
+	}
+
+	if data.Scope != "" {
+		text = data.Scope + valueOrDefault(l.ScopeDelimiter, DefaultScopeDelimiter) + text
+	}
+
+	if l.CodeFormat != "" && data.Code > 0 {
+		text = fmt.Sprintf(l.CodeFormat, data.Code) + text
+	}
+
+	return text
+}
+
+// wrap returns error with rendered text, which wraps given cause...
+func (l *Layout) wrap(cause error, data LayoutData) error {
+	// text of cause can contain sensitive data, e.g. error of third-party library with key in text
+	data.Cause = Redact(cause.Error())
+
+	return &textError{
+		message: l.render(data),
+		cause:   cause,
+	}
+}
+
+func valueOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+// textError - error with prepared text, keeps previous error of cause chain...
+type textError struct {
+	message string
+	cause   error
+}
+
+// Error to string converter...
+func (e *textError) Error() string {
+	return e.message
+}
+
+// Unwrap returns previous error...
+func (e *textError) Unwrap() error {
+	return e.cause
+}
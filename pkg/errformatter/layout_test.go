@@ -0,0 +1,132 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	t.Run("valued formatter - custom delimiters and code rendering", func(t *testing.T) {
+		const (
+			expectedResult        = "[E1042] wallet | test error :: detail_1; detail_2"
+			expectedReWrapResult  = "api | [E1042] wallet | test error :: detail_1; detail_2 :: detail_3"
+			expectedTextForWrap   = "test error"
+			expectedLayoutErrCode = 1042
+		)
+
+		var errorForWrap = errors.New(expectedTextForWrap)
+
+		layout := Layout{
+			ScopeDelimiter:   " | ",
+			DetailsDelimiter: " :: ",
+			DetailsJoiner:    "; ",
+			CodeFormat:       "[E%d] ",
+			Template:         nil,
+		}
+
+		svc := NewValuesErrorFormatterWithOptions([]Value{
+			NewValue(KindScope, "wallet"),
+			NewValue(KindCode, expectedLayoutErrCode),
+		}, WithLayout(layout))
+
+		err := svc.Error(errorForWrap, "detail_1", "detail_2")
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		reWrapErr := NewValuesErrorFormatterWithOptions([]Value{
+			NewValue(KindScope, "api"),
+		}, WithLayout(layout)).ErrorOnly(err, "detail_3")
+		if reWrapErr.Error() != expectedReWrapResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				reWrapErr.Error(), expectedReWrapResult)
+		}
+
+		if !errors.Is(reWrapErr, errorForWrap) {
+			t.Errorf("error text not equal with expected. current: %e, expected: %e",
+				reWrapErr, errorForWrap)
+		}
+	})
+
+	t.Run("scoped formatter - template layout", func(t *testing.T) {
+		const expectedResult = "scope=wallet cause=test error details=detail_1;detail_2"
+
+		layout, err := NewTemplateLayout(`scope={{.Scope}} cause={{.Cause}} details={{join .Details ";"}}`)
+		if err != nil {
+			t.Fatalf("unable to create template layout: %s", err)
+		}
+
+		svc := NewScopedErrorFormatter("wallet", WithLayout(layout))
+
+		scopedErr := svc.Error(errors.New("test error"), "detail_1", "detail_2")
+		if scopedErr.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				scopedErr.Error(), expectedResult)
+		}
+
+		if _, err = NewTemplateLayout("{{.Scope"); err == nil {
+			t.Errorf("invalid template must return error")
+		}
+	})
+
+	t.Run("package functions - default layout", func(t *testing.T) {
+		const (
+			expectedResult    = "wallet / test error => detail_1 + detail_2"
+			expectedNewResult = "wallet / detail_1 + detail_2"
+		)
+
+		//nolint:exhaustruct // it's ok - empty fields are replaced by default values
+		SetDefaultLayout(Layout{
+			ScopeDelimiter:   " / ",
+			DetailsDelimiter: " => ",
+			DetailsJoiner:    " + ",
+		})
+		//nolint:exhaustruct // it's ok - zero layout is layout with default values
+		defer SetDefaultLayout(Layout{})
+
+		err := ScopedErrorOnly(errors.New("test error"), "wallet", "detail_1", "detail_2")
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		newErr := NewScopedError("wallet", "detail_1", "detail_2")
+		if newErr.Error() != expectedNewResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				newErr.Error(), expectedNewResult)
+		}
+	})
+}
@@ -0,0 +1,316 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// MaxCompactErrorsCount - max count of member errors, which are rendered in compact text of multi-error...
+const MaxCompactErrorsCount = 3
+
+var (
+	_ slog.LogValuer = (*multiError)(nil)
+	_ json.Marshaler = (*multiError)(nil)
+)
+
+// multiError - aggregate error of batch operation. Values of aggregate error are stored in valuedError,
+// member errors are available via Unwrap() []error, so errors.Is and errors.As works with all members...
+type multiError struct {
+	valued *valuedError
+	errs   []error
+}
+
+// Error returns compact text of multi-error - count of errors and text of first errors...
+func (e *multiError) Error() string {
+	return e.valued.Error()
+}
+
+// Unwrap returns member errors...
+func (e *multiError) Unwrap() []error {
+	return e.errs
+}
+
+// As sets target to aggregate valued error, so code and values getters return values of aggregate error...
+func (e *multiError) As(target any) bool {
+	valuedTarget, isValuedTarget := target.(**valuedError)
+	if !isValuedTarget {
+		return false
+	}
+
+	*valuedTarget = e.valued
+
+	return true
+}
+
+// Format implements fmt.Formatter interface, %+v renders multi-line report with text of all member errors...
+func (e *multiError) Format(state fmt.State, verb rune) {
+	formatError(state, verb, e, e.verboseReport)
+}
+
+func (e *multiError) verboseReport() string {
+	var report verboseReport
+
+	report.writeLine(e.valued.verboseReport())
+	report.writeList("errors", errorsText(e.errs))
+
+	return report.String()
+}
+
+// LogValue implements slog.LogValuer interface, returns group with values of aggregate error...
+func (e *multiError) LogValue() slog.Value {
+	return e.valued.LogValue()
+}
+
+// MarshalJSON implements json.Marshaler interface, returns json of aggregate error...
+func (e *multiError) MarshalJSON() ([]byte, error) {
+	return e.valued.MarshalJSON()
+}
+
+// NewMultiError returns aggregate error of given errors, nil errors are skipped. Returns nil if all errors are nil.
+// Code and public code of aggregate error are taken from member error with highest severity value,
+// from first member error with code if severities of member errors are unknown...
+func NewMultiError(values []Value, errs ...error) error {
+	multiErr := newMultiError(newOptions(), values, errs)
+	if multiErr == nil {
+		return nil
+	}
+
+	errorObservers.notify(multiErr)
+
+	return multiErr
+}
+
+func newMultiError(opts options, values []Value, errs []error) *multiError {
+	errs = slices.DeleteFunc(slices.Clone(errs), func(err error) bool {
+		return err == nil
+	})
+	if len(errs) == 0 {
+		return nil
+	}
+
+	vErr := &valuedError{
+		Err:          nil,
+		values:       [MaxKindValue + 1]Value{},
+		custom:       nil,
+		stack:        nil,
+		previous:     nil,
+		scopePath:    "",
+		layout:       opts.layout,
+		renderedCode: 0,
+		isSentinel:   false,
+		settled:      0,
+	}
+
+	_ = vErr.setValues(values...)
+
+	code, publicCode := aggregateCodes(opts.catalog, errs)
+	if code != ValueCodeMissing && !vErr.settled.Has(ValueCodeIsSet) {
+		_ = vErr.setValue(NewValue(KindCode, code))
+	}
+
+	if publicCode != ValueCodeMissing && !vErr.settled.Has(ValuePublicCodeIsSet) {
+		_ = vErr.setValue(NewValue(KindPublicCode, publicCode))
+	}
+
+	// member errors are kept in text error of aggregate, so members are available after re-wrap of aggregate
+	vErr.Err = &joinedError{
+		message: vErr.renderMulti(errs),
+		errs:    errs,
+	}
+
+	return &multiError{
+		valued: vErr,
+		errs:   errs,
+	}
+}
+
+// renderMulti returns compact text of multi-error by layout of error...
+func (e *valuedError) renderMulti(errs []error) string {
+	data := LayoutData{
+		Scope:   "",
+		Code:    0,
+		Cause:   "",
+		Details: nil,
+	}
+
+	if e.settled.Has(ValueScopeIsSet) {
+		data.Scope = e.values[KindScope].getScope()
+	}
+
+	if code := e.getCode(); code > 0 {
+		data.Code = code
+		e.renderedCode = code
+	}
+
+	texts := errorsText(errs)
+
+	switch {
+	case len(texts) == 1:
+		data.Cause = texts[0]
+	case len(texts) > MaxCompactErrorsCount:
+		data.Cause = fmt.Sprintf("%d errors", len(texts))
+		data.Details = append(texts[:MaxCompactErrorsCount],
+			fmt.Sprintf("and %d more", len(texts)-MaxCompactErrorsCount))
+	default:
+		data.Cause = fmt.Sprintf("%d errors", len(texts))
+		data.Details = texts
+	}
+
+	return resolveLayout(e.layout).render(data)
+}
+
+// aggregateCodes returns code and public code of member error with highest severity. Severity of member error
+// is taken from severity values of error, severity of code in catalog is used if error has no severity value.
+// Code of first member error with code is returned if severities of member errors are unknown...
+func aggregateCodes(catalog *Catalog, errs []error) (int, int) {
+	var (
+		code         = ValueCodeMissing
+		publicCode   = ValueCodeMissing
+		bestSeverity = SeverityUnknown
+	)
+
+	for i := range errs {
+		memberCode := ValuedErrorGetCode(errs[i])
+		if memberCode == ValueCodeMissing {
+			continue
+		}
+
+		severity := ErrorSeverity(errs[i])
+		if severity == SeverityUnknown && catalog != nil {
+			if info, isRegistered := catalog.Lookup(memberCode); isRegistered {
+				severity = info.Severity
+			}
+		}
+
+		if code != ValueCodeMissing && severity <= bestSeverity {
+			continue
+		}
+
+		code, bestSeverity = memberCode, severity
+		publicCode = ValuedErrorGetPublicCode(errs[i])
+	}
+
+	return code, publicCode
+}
+
+// joinedError - error with prepared text, keeps list of member errors...
+type joinedError struct {
+	message string
+	errs    []error
+}
+
+// Error to string converter...
+func (e *joinedError) Error() string {
+	return e.message
+}
+
+// Unwrap returns member errors...
+func (e *joinedError) Unwrap() []error {
+	return e.errs
+}
+
+func errorsText(errs []error) []string {
+	texts := make([]string, len(errs))
+	for i := range errs {
+		texts[i] = Redact(errs[i].Error())
+	}
+
+	return texts
+}
+
+// Collector accumulates errors of batch operation, e.g. signing of many transactions.
+// Collector is safe for concurrent usage...
+type Collector struct {
+	mu sync.Mutex
+
+	values  []Value
+	options options
+	errs    []error
+}
+
+// Add adds error to collector, nil error is skipped...
+func (c *Collector) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.errs = append(c.errs, err)
+}
+
+// Len returns count of collected errors...
+func (c *Collector) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.errs)
+}
+
+// Errors returns copy of list of collected errors...
+func (c *Collector) Errors() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.errs)
+}
+
+// Err returns aggregate error of collected errors or nil if collector has no errors. Code of aggregate error
+// is code of member error with highest severity value, severity of code in catalog of WithCatalog option
+// is used for member errors without severity value...
+func (c *Collector) Err() error {
+	multiErr := newMultiError(c.options, c.values, c.Errors())
+	if multiErr == nil {
+		return nil
+	}
+
+	errorObservers.notify(multiErr)
+
+	return multiErr
+}
+
+// NewCollector returns collector of errors. Given values and options are used for aggregate error...
+func NewCollector(values []Value, opts ...Option) *Collector {
+	return &Collector{
+		mu:      sync.Mutex{},
+		values:  values,
+		options: newOptions(opts...),
+		errs:    nil,
+	}
+}
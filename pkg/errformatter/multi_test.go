@@ -0,0 +1,186 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	t.Run("collector - concurrent collect of errors and aggregate code by severity", func(t *testing.T) {
+		const (
+			expectedResult = "batch_sign: 5 errors -> tx_0: wallet not found, tx_1: hsm unreachable, " +
+				"tx_2: wallet not found, and 2 more"
+			expectedCode       = 500
+			expectedErrorCount = 5
+		)
+
+		errWalletNotFound := errors.New("wallet not found")
+		errHSMUnreachable := errors.New("hsm unreachable")
+
+		svc := NewErrorFormatter()
+		collector := NewCollector([]Value{NewValue(KindScope, "batch_sign")}, WithCatalog(newTestCatalog()))
+
+		var wg sync.WaitGroup
+
+		errs := make([]error, expectedErrorCount)
+		for i := range errs {
+			switch i % 2 {
+			case 0:
+				errs[i] = svc.ErrorWithCode(ScopedErrorOnly(errWalletNotFound, "tx_"+strconv.Itoa(i)), 404)
+			default:
+				errs[i] = svc.ErrorWithCode(ScopedErrorOnly(errHSMUnreachable, "tx_"+strconv.Itoa(i)), 500)
+			}
+		}
+
+		for i := range errs {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				collector.Add(errs[i])
+				collector.Add(nil)
+			}(i)
+		}
+
+		wg.Wait()
+
+		if collector.Len() != expectedErrorCount {
+			t.Errorf("count of errors not equal with expected. current: %d, expected: %d",
+				collector.Len(), expectedErrorCount)
+		}
+
+		err := NewCollector([]Value{NewValue(KindScope, "batch_sign")},
+			WithCatalog(newTestCatalog())).Err()
+		if err != nil {
+			t.Errorf("empty collector must return nil error. current: %s", err)
+		}
+
+		// order of errors of concurrent collect is not determined, so text checked with ordered collect
+		orderedCollector := NewCollector([]Value{NewValue(KindScope, "batch_sign")}, WithCatalog(newTestCatalog()))
+		for i := range errs {
+			orderedCollector.Add(errs[i])
+		}
+
+		err = orderedCollector.Err()
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		if code := ValuedErrorGetCode(err); code != expectedCode {
+			t.Errorf("error code not equal with expected. current: %d, expected: %d",
+				code, expectedCode)
+		}
+
+		if code := ValuedErrorGetCode(collector.Err()); code != expectedCode {
+			t.Errorf("error code not equal with expected. current: %d, expected: %d",
+				code, expectedCode)
+		}
+
+		if !errors.Is(err, errWalletNotFound) || !errors.Is(err, errHSMUnreachable) {
+			t.Errorf("multi-error must match all member errors. current: %s", err)
+		}
+
+		var scopedErr *scopedError
+		if !errors.As(err, &scopedErr) || scopedErr.scope != "tx_0" {
+			t.Errorf("multi-error must be convertible to member error. current: %s", err)
+		}
+	})
+
+	t.Run("multi-error - aggregate code by severity value of member error", func(t *testing.T) {
+		const expectedCode = 100500
+
+		svc := NewErrorFormatter()
+
+		// code 404 is registered in catalog with warning severity, code 100500 is not registered
+		warningErr := svc.ErrorWithCode(errors.New("wallet not found"), 404)
+		criticalErr := svc.ErrorWithSeverity(svc.ErrorWithCode(errors.New("key leaked"), expectedCode),
+			SeverityCritical)
+
+		err := newMultiError(newOptions(WithCatalog(newTestCatalog())), nil, []error{warningErr, criticalErr})
+		if code := ValuedErrorGetCode(err); code != expectedCode {
+			t.Errorf("error code not equal with expected. current: %d, expected: %d", code, expectedCode)
+		}
+	})
+
+	t.Run("multi-error - detailed text", func(t *testing.T) {
+		const expectedResult = "2 errors -> first, second\n" +
+			"errors:\n" +
+			"    - first\n" +
+			"    - second"
+
+		err := NewMultiError(nil, errors.New("first"), nil, errors.New("second"))
+
+		verboseText := fmt.Sprintf("%+v", err)
+		if verboseText != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				verboseText, expectedResult)
+		}
+
+		if !strings.HasPrefix(fmt.Sprintf("%v", err), "2 errors") {
+			t.Errorf("error text not equal with expected. current: %v, expected: %s", err, "2 errors")
+		}
+
+		if NewMultiError(nil, nil, nil) != nil {
+			t.Errorf("multi-error of nil errors must be nil")
+		}
+	})
+
+	t.Run("multi-error - member errors available after re-wrap", func(t *testing.T) {
+		errFirst := errors.New("first")
+		errSecond := errors.New("second")
+
+		err := NewValuesErrorFormatter(NewValue(KindScope, "batch")).
+			ErrorOnly(NewMultiError(nil, errFirst, errSecond), "re_wrap_detail")
+		if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+			t.Errorf("re-wrapped multi-error must match all member errors. current: %s", err)
+		}
+	})
+
+	t.Run("multi-error - one member error", func(t *testing.T) {
+		const expectedResult = "batch: only error"
+
+		err := NewMultiError([]Value{NewValue(KindScope, "batch")}, errors.New("only error"))
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+	})
+}
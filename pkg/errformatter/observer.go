@@ -0,0 +1,184 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Event - information about valued error, passed to registered observers once per call of formatter method
+// or constructor function, after all values of call are applied to error...
+type Event struct {
+	// Err - constructed error
+	Err error
+	// Scope - scope of constructed error node, empty if scope value is not set
+	Scope string
+	// Code - code of error or ValueCodeMissing if code value is not set
+	Code int
+	// Severity - max severity of cause chain of error, see ErrorSeverity function
+	Severity Severity
+	// IsRewrap - error is created by re-wrap of existing valued error, e.g. code is added to error of other call
+	IsRewrap bool
+	// PreviousScope - scope of re-wrapped error, empty if IsRewrap is false
+	PreviousScope string
+	// PreviousCode - code of re-wrapped error, ValueCodeMissing if IsRewrap is false
+	PreviousCode int
+	// PreviousSeverity - max severity of cause chain of re-wrapped error, unknown if IsRewrap is false
+	PreviousSeverity Severity
+}
+
+// Observer - function, which is called on each construction of valued error, e.g. collector of error metrics.
+// Observer is called synchronously in goroutine of error construction, so it must be fast and concurrent-safe...
+type Observer func(event Event)
+
+type observerEntry struct {
+	observer Observer
+}
+
+type observerRegistry struct {
+	mu sync.RWMutex
+
+	// count - count of registered observers, lets skip building of events if there are no observers
+	count     atomic.Int32
+	observers []*observerEntry
+}
+
+//nolint:gochecknoglobals // it's ok - registry of observers must be shared by all formatters
+var errorObservers = &observerRegistry{
+	mu:        sync.RWMutex{},
+	count:     atomic.Int32{},
+	observers: make([]*observerEntry, 0),
+}
+
+func (r *observerRegistry) register(observer Observer) func() {
+	if observer == nil {
+		panic("errfmt: observer must be not nil")
+	}
+
+	entry := &observerEntry{
+		observer: observer,
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.observers = append(r.observers, entry)
+	r.count.Store(int32(len(r.observers))) //nolint:gosec // it's ok - count of observers is small
+
+	return func() {
+		r.unregister(entry)
+	}
+}
+
+func (r *observerRegistry) unregister(entry *observerEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.observers = slices.DeleteFunc(slices.Clone(r.observers), func(current *observerEntry) bool {
+		return current == entry
+	})
+	r.count.Store(int32(len(r.observers))) //nolint:gosec // it's ok - count of observers is small
+}
+
+// notify notifies observers about constructed error. Values of event are taken from valued node of error,
+// values of aggregate error are used for multi-error...
+func (r *observerRegistry) notify(err error) {
+	if r.count.Load() == 0 {
+		return
+	}
+
+	r.mu.RLock()
+	observers := r.observers
+	r.mu.RUnlock()
+
+	if len(observers) == 0 {
+		return
+	}
+
+	var vErr *valuedError
+	if !errors.As(err, &vErr) {
+		return
+	}
+
+	event := Event{
+		Err:              err,
+		Scope:            vErr.getScope(),
+		Code:             vErr.getCode(),
+		Severity:         ErrorSeverity(err),
+		IsRewrap:         vErr.previous != nil,
+		PreviousScope:    "",
+		PreviousCode:     ValueCodeMissing,
+		PreviousSeverity: SeverityUnknown,
+	}
+
+	if event.IsRewrap {
+		event.PreviousScope = vErr.previous.getScope()
+		event.PreviousCode = vErr.previous.getCode()
+		event.PreviousSeverity = ErrorSeverity(vErr.previous)
+	}
+
+	for i := range observers {
+		observers[i].observer(event)
+	}
+}
+
+// RegisterObserver registers observer of construction of valued errors. Observers are notified once per call
+// of formatter method, ValuedError* function and constructor of multi-error, if valued error is returned.
+// Sentinel errors and errors restored from json or gRPC status are not observed.
+// Returns function, which unregisters observer...
+func RegisterObserver(observer Observer) func() {
+	return errorObservers.register(observer)
+}
+
+// observed notifies registered observers about constructed error and returns it as error interface,
+// nil error node is returned as untyped nil. It must be called once by public method,
+// after all values are applied to error...
+func observed(vErr *valuedError) error {
+	if vErr == nil {
+		return nil
+	}
+
+	return observedValued(vErr)
+}
+
+// observedValued same with observed, but returns error node, used by exported functions with *valuedError result...
+func observedValued(vErr *valuedError) *valuedError {
+	if vErr != nil {
+		errorObservers.notify(vErr)
+	}
+
+	return vErr
+}
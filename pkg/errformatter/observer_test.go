@@ -0,0 +1,234 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"testing"
+)
+
+// recordObserver registers observer, which records events of errors with given scope...
+func recordObserver(t *testing.T, scope string) *[]Event {
+	t.Helper()
+
+	events := make([]Event, 0)
+
+	unregister := RegisterObserver(func(event Event) {
+		if event.Scope == scope {
+			events = append(events, event)
+		}
+	})
+	t.Cleanup(unregister)
+
+	return &events
+}
+
+func TestRegisterObserver(t *testing.T) {
+	t.Run("valued formatter - one event per call, re-wrap with code is observed with code", func(t *testing.T) {
+		const (
+			expectedScope = "observed_scope"
+			expectedCode  = 1042
+		)
+
+		events := recordObserver(t, expectedScope)
+
+		svc := New(WithValues(NewValue(KindScope, expectedScope)), WithSeverity(SeverityWarning))
+
+		err := svc.NewError("detail_1")
+		err = svc.ErrorWithCode(err, expectedCode)
+
+		if len(*events) != 2 {
+			t.Fatalf("count of events not equal with expected. current: %d, expected: %d", len(*events), 2)
+		}
+
+		first, second := (*events)[0], (*events)[1]
+		if first.IsRewrap || first.Code != ValueCodeMissing || first.Severity != SeverityWarning {
+			t.Errorf("first event not equal with expected. current: %+v", first)
+		}
+
+		if !second.IsRewrap || second.Code != expectedCode || !errors.Is(second.Err, err) {
+			t.Errorf("second event not equal with expected. current: %+v", second)
+		}
+
+		if second.PreviousScope != expectedScope || second.PreviousCode != ValueCodeMissing ||
+			second.PreviousSeverity != SeverityWarning {
+			t.Errorf("values of re-wrapped error not equal with expected. current: %+v", second)
+		}
+	})
+
+	t.Run("error with code and catalog - one event with final values", func(t *testing.T) {
+		const (
+			expectedScope      = "observed_catalog_scope"
+			expectedCode       = 1043
+			expectedPublicCode = 409
+		)
+
+		catalog := NewCatalog().MustRegister(CodeInfo{
+			Code:        expectedCode,
+			Name:        "observed_conflict",
+			Description: "",
+			PublicCode:  expectedPublicCode,
+			HTTPStatus:  0,
+			GRPCCode:    0,
+			Severity:    SeverityCritical,
+		})
+
+		events := recordObserver(t, expectedScope)
+
+		formatters := []Formatter{
+			New(WithCatalog(catalog)).WithScope(expectedScope),
+			New(WithCatalog(catalog), WithValues(NewValue(KindScope, expectedScope))),
+			New(WithCatalog(catalog), WithScope(expectedScope)),
+		}
+
+		for i := range formatters {
+			*events = (*events)[:0]
+
+			err := formatters[i].ErrorWithCode(errors.New("test error"), expectedCode)
+
+			if len(*events) != 1 {
+				t.Fatalf("count of events not equal with expected. current: %d, expected: %d", len(*events), 1)
+			}
+
+			event := (*events)[0]
+			if event.IsRewrap || event.Code != expectedCode || event.Severity != SeverityCritical {
+				t.Errorf("event not equal with expected. current: %+v", event)
+			}
+
+			if publicCode := ValuedErrorGetPublicCode(event.Err); publicCode != expectedPublicCode {
+				t.Errorf("public code not equal with expected. current: %d, expected: %d",
+					publicCode, expectedPublicCode)
+			}
+
+			if !errors.Is(event.Err, err) {
+				t.Errorf("error of event not equal with expected. current: %s, expected: %s", event.Err, err)
+			}
+		}
+	})
+
+	t.Run("plain formatter and catalog - error with code is observed once", func(t *testing.T) {
+		const expectedCode = 1044
+
+		var codeEvents []Event
+
+		unregister := RegisterObserver(func(event Event) {
+			if event.Code == expectedCode {
+				codeEvents = append(codeEvents, event)
+			}
+		})
+		defer unregister()
+
+		catalog := NewCatalog().MustRegister(CodeInfo{
+			Code:        expectedCode,
+			Name:        "observed_plain",
+			Description: "",
+			PublicCode:  400,
+			HTTPStatus:  0,
+			GRPCCode:    0,
+			Severity:    SeverityWarning,
+		})
+
+		_ = New(WithCatalog(catalog)).ErrorWithCode(errors.New("test error"), expectedCode)
+
+		if len(codeEvents) != 1 {
+			t.Fatalf("count of events not equal with expected. current: %d, expected: %d", len(codeEvents), 1)
+		}
+
+		if codeEvents[0].Severity != SeverityWarning {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s",
+				codeEvents[0].Severity, SeverityWarning)
+		}
+	})
+
+	t.Run("sentinel definition - not observed", func(t *testing.T) {
+		const expectedScope = "observed_sentinel_scope"
+
+		events := recordObserver(t, expectedScope)
+
+		_ = Define(expectedScope, 1045, "sentinel error")
+
+		if len(*events) != 0 {
+			t.Errorf("count of events not equal with expected. current: %d, expected: %d", len(*events), 0)
+		}
+	})
+
+	t.Run("multi-error - event with max severity of members", func(t *testing.T) {
+		const expectedScope = "observed_multi_scope"
+
+		events := recordObserver(t, expectedScope)
+
+		memberErr := MultiValuedErrorOnly(errors.New("member error"), NewSeverityValue(SeverityCritical))
+
+		_ = NewMultiError([]Value{NewValue(KindScope, expectedScope)}, memberErr, errors.New("test error"))
+
+		if len(*events) != 1 {
+			t.Fatalf("count of events not equal with expected. current: %d, expected: %d", len(*events), 1)
+		}
+
+		if (*events)[0].Severity != SeverityCritical {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s",
+				(*events)[0].Severity, SeverityCritical)
+		}
+	})
+
+	t.Run("unregistered observer - no events", func(t *testing.T) {
+		const expectedScope = "unregistered_scope"
+
+		var eventsCount int
+
+		unregister := RegisterObserver(func(event Event) {
+			if event.Scope == expectedScope {
+				eventsCount++
+			}
+		})
+		unregister()
+
+		_ = ValuedNewError([]Value{NewValue(KindScope, expectedScope)}, "detail_1")
+
+		if eventsCount != 0 {
+			t.Errorf("count of events not equal with expected. current: %d, expected: %d", eventsCount, 0)
+		}
+	})
+
+	t.Run("plain formatter without values - errors are not observed", func(t *testing.T) {
+		const expectedScope = "plain_scope"
+
+		events := recordObserver(t, expectedScope)
+
+		_ = New(WithScope(expectedScope)).NewError("detail_1")
+
+		if len(*events) != 0 {
+			t.Errorf("count of events not equal with expected. current: %d, expected: %d", len(*events), 0)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+// Option is optional setting of formatter service...
+type Option func(opts *options)
+
+type options struct {
+	// isStackCaptureEnabled - capture stack trace of errors, created by service,
+	// even if package-level stack capture switch is disabled
+	isStackCaptureEnabled bool
+	// catalog - catalog of error codes, ErrorWithCode sets default public code and severity of registered codes
+	catalog *Catalog
+	// layout - layout of error text, package-level default layout is used if layout is nil
+	layout *Layout
+	// severity - default severity of errors, created or wrapped by service, not set if severity is unknown
+	severity Severity
+	// scope - scope of errors, used by New function for scoped and valued modes
+	scope string
+	// values - default values of errors, used by New function for valued mode
+	values []Value
+	// isValued - valued mode of formatter, created by New function
+	isValued bool
+}
+
+// WithStackCapture enables stack capture for all valued errors, created by formatter service...
+func WithStackCapture() Option {
+	return func(opts *options) {
+		opts.isStackCaptureEnabled = true
+	}
+}
+
+// WithCatalog sets catalog of error codes. ErrorWithCode sets default public code and severity of registered code,
+// codes, which are not registered in catalog, are attached without catalog metadata. Registration of codes
+// is checked by errfmtcheck analyzer and MustRegister at init time. Formatters in valued mode take public code
+// and severity of code value from WithValues option from catalog too...
+func WithCatalog(catalog *Catalog) Option {
+	return func(opts *options) {
+		opts.catalog = catalog
+	}
+}
+
+// WithLayout sets layout of text of errors, created by formatter service...
+func WithLayout(layout Layout) Option {
+	return func(opts *options) {
+		opts.layout = &layout
+	}
+}
+
+// WithScope sets scope of errors, created by formatter service. Formatter works in scoped mode,
+// or in valued mode with scope value if WithValues option is set too...
+func WithScope(scope string) Option {
+	return func(opts *options) {
+		opts.scope = scope
+	}
+}
+
+// WithValues switches formatter service to valued mode, all errors of formatter are valued errors
+// with given default values...
+func WithValues(values ...Value) Option {
+	return func(opts *options) {
+		opts.values = append(opts.values, values...)
+		opts.isValued = true
+	}
+}
+
+// WithSeverity sets default severity of errors, created or wrapped by formatter service...
+func WithSeverity(severity Severity) Option {
+	return func(opts *options) {
+		opts.severity = severity
+	}
+}
+
+// severityValues returns list with value of default severity or empty list if default severity is not set...
+func (o *options) severityValues() []Value {
+	if o.severity == SeverityUnknown {
+		return nil
+	}
+
+	return []Value{NewSeverityValue(o.severity)}
+}
+
+// valuesWith returns list with value of default severity and given values at the end...
+func (o *options) valuesWith(values []Value) []Value {
+	return append(o.severityValues(), values...)
+}
+
+// catalogValues returns values of code from catalog or empty list if code is not registered...
+func (o *options) catalogValues(code int) []Value {
+	if o.catalog == nil {
+		return nil
+	}
+
+	// code can be computed at runtime, so error path must not panic on code, which is not registered
+	info, isRegistered := o.catalog.Lookup(code)
+	if !isRegistered {
+		return nil
+	}
+
+	values := make([]Value, 0, 2) //nolint:mnd // it's ok - public code and severity values
+
+	if info.PublicCode > 0 {
+		values = append(values, NewValue(KindPublicCode, info.PublicCode))
+	}
+
+	if info.Severity != SeverityUnknown {
+		values = append(values, NewSeverityValue(info.Severity))
+	}
+
+	return values
+}
+
+func newOptions(opts ...Option) options {
+	result := options{
+		isStackCaptureEnabled: false,
+		catalog:               nil,
+		layout:                nil,
+		severity:              SeverityUnknown,
+		scope:                 "",
+		values:                nil,
+		isValued:              false,
+	}
+
+	for i := range opts {
+		opts[i](&result)
+	}
+
+	return result
+}
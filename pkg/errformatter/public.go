@@ -0,0 +1,99 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultPublicMessage - client-facing message of errors without public code or without registered public message...
+const DefaultPublicMessage = "internal error"
+
+type publicMessageRegistry struct {
+	mu       sync.RWMutex
+	messages map[int]string
+}
+
+//nolint:gochecknoglobals // it's ok - registry of public messages must be shared by all formatters
+var publicMessages = &publicMessageRegistry{
+	mu:       sync.RWMutex{},
+	messages: make(map[int]string),
+}
+
+// RegisterPublicMessage registers client-facing message of public code. Must be called at init time...
+func RegisterPublicMessage(publicCode int, message string) {
+	if publicCode <= 0 {
+		panic("errfmt: public code must be positive value")
+	}
+
+	publicMessages.mu.Lock()
+	defer publicMessages.mu.Unlock()
+
+	if _, isExists := publicMessages.messages[publicCode]; isExists {
+		panic(fmt.Sprintf("errfmt: message of public code %d already registered", publicCode))
+	}
+
+	publicMessages.messages[publicCode] = message
+}
+
+// PublicMessage returns registered client-facing message of public code...
+func PublicMessage(publicCode int) (string, bool) {
+	publicMessages.mu.RLock()
+	defer publicMessages.mu.RUnlock()
+
+	message, isExists := publicMessages.messages[publicCode]
+
+	return message, isExists
+}
+
+// Public returns client-facing view of error - public code and public message. Scope, details, causes
+// and text of error are never part of public view. Public message is message registered by RegisterPublicMessage,
+// or DefaultPublicMessage. Public code is ValueCodeMissing for errors without public code...
+func Public(err error) (int, string) {
+	if err == nil {
+		return ValueCodeMissing, ""
+	}
+
+	publicCode := ValuedErrorGetPublicCode(err)
+	if publicCode == ValueCodeMissing {
+		return ValueCodeMissing, DefaultPublicMessage
+	}
+
+	message, isExists := PublicMessage(publicCode)
+	if !isExists {
+		return publicCode, DefaultPublicMessage
+	}
+
+	return publicCode, message
+}
@@ -0,0 +1,104 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"testing"
+)
+
+//nolint:gochecknoinits // it's ok - public messages must be registered once
+func init() {
+	RegisterPublicMessage(1042, "wallet not found")
+}
+
+func TestPublicCode(t *testing.T) {
+	t.Run("all services - ErrorWithPublicCode + ErrorGetPublicCode", func(t *testing.T) {
+		const expectedPublicCode = 1042
+
+		services := map[string]Formatter{
+			"plain":               NewErrorFormatter(),
+			"scoped":              NewScopedErrorFormatter("scope"),
+			"valued":              NewValuesErrorFormatter(),
+			"valued with default": NewValuesErrorFormatter(NewValue(KindScope, "scope")),
+		}
+
+		for name, svc := range services {
+			err := svc.ErrWithPublicCode(svc.ErrorWithCode(errors.New("test error"), 404), expectedPublicCode)
+
+			if code := svc.ErrGetPublicCode(err); code != expectedPublicCode {
+				t.Errorf("%s service - public code not equal with expected. current: %d, expected: %d",
+					name, code, expectedPublicCode)
+			}
+
+			if code := ValuedErrorGetCode(err); code != 404 {
+				t.Errorf("%s service - code not equal with expected. current: %d, expected: %d",
+					name, code, 404)
+			}
+		}
+
+		if code := ValuedErrorGetPublicCode(errors.New("test error")); code != ValueCodeMissing {
+			t.Errorf("public code not equal with expected. current: %d, expected: %d",
+				code, ValueCodeMissing)
+		}
+	})
+
+	t.Run("public view - scope, details and text are stripped", func(t *testing.T) {
+		svc := NewValuesErrorFormatter(NewValue(KindScope, "wallet_storage"))
+
+		code, message := Public(svc.ErrorWithPublicCode(svc.Error(errors.New("sql: no rows"), "wallet_id=1"), 1042))
+		if code != 1042 || message != "wallet not found" {
+			t.Errorf("public view not equal with expected. current: %d, %s", code, message)
+		}
+
+		code, message = Public(svc.ErrorWithPublicCode(errors.New("sql: no rows"), 1043))
+		if code != 1043 || message != DefaultPublicMessage {
+			t.Errorf("public view not equal with expected. current: %d, %s", code, message)
+		}
+
+		code, message = Public(svc.Error(errors.New("sql: no rows"), "wallet_id=1"))
+		if code != ValueCodeMissing || message != DefaultPublicMessage {
+			t.Errorf("public view not equal with expected. current: %d, %s", code, message)
+		}
+	})
+
+	t.Run("public code must be positive", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("not positive public code must panic")
+			}
+		}()
+
+		_ = NewErrorFormatter().ErrorWithPublicCode(errors.New("test error"), 0)
+	})
+}
@@ -0,0 +1,199 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+const (
+	// CodePanic - default code of errors, converted from recovered panic values...
+	CodePanic = 999
+
+	// recoverStackSkip - frames of Recover function and runtime panic function
+	recoverStackSkip = 2
+)
+
+// ErrPanic - sentinel error, which matches errors of recovered panic values with any code.
+// Errors with CodePanic code, which are not built by Recover function, don't match it...
+var ErrPanic = errors.New("panic")
+
+// panicError - cause of error of recovered panic value, matches ErrPanic and wraps panic value of error type...
+type panicError struct {
+	value error
+}
+
+// Error to string converter...
+func (e *panicError) Error() string {
+	return ErrPanic.Error()
+}
+
+// Unwrap returns panic value of error type or nil...
+func (e *panicError) Unwrap() error {
+	return e.value
+}
+
+// Is reports whether target is ErrPanic...
+func (e *panicError) Is(target error) bool {
+	return target == ErrPanic //nolint:errorlint // it's ok - identity of sentinel error
+}
+
+// RecoverOption is optional setting of Recover and SafeGo functions...
+type RecoverOption func(opts *recoverOptions)
+
+type recoverOptions struct {
+	// formatter - formatter service of error, scope and default values of formatter are attached to error
+	formatter Formatter
+	// code - code of error, CodePanic by default
+	code int
+	// repanics - matchers of panic values, which are re-panicked instead of conversion to error
+	repanics []func(value any) bool
+	// handler - handler of errors of SafeGo function
+	handler func(err error)
+}
+
+// WithRecoverFormatter sets formatter service, which builds error of recovered panic value...
+func WithRecoverFormatter(formatter Formatter) RecoverOption {
+	return func(opts *recoverOptions) {
+		opts.formatter = formatter
+	}
+}
+
+// WithPanicCode sets code of error of recovered panic value...
+func WithPanicCode(code int) RecoverOption {
+	if code <= 0 {
+		panic("errfmt: code must be positive value")
+	}
+
+	return func(opts *recoverOptions) {
+		opts.code = code
+	}
+}
+
+// WithRepanicType re-panics panic values of given type instead of conversion to error...
+func WithRepanicType[T any]() RecoverOption {
+	return func(opts *recoverOptions) {
+		opts.repanics = append(opts.repanics, func(value any) bool {
+			_, isMatched := value.(T)
+
+			return isMatched
+		})
+	}
+}
+
+// WithPanicHandler sets handler of errors of SafeGo function, by default errors are logged by slog.Default logger.
+// Nil handler is ignored...
+func WithPanicHandler(handler func(err error)) RecoverOption {
+	return func(opts *recoverOptions) {
+		if handler != nil {
+			opts.handler = handler
+		}
+	}
+}
+
+func newRecoverOptions(opts ...RecoverOption) recoverOptions {
+	result := recoverOptions{
+		formatter: nil,
+		code:      CodePanic,
+		repanics:  nil,
+		handler: func(err error) {
+			slog.Default().Error("goroutine is finished with error", slog.Any("err", err))
+		},
+	}
+
+	for i := range opts {
+		opts[i](&result)
+	}
+
+	if result.formatter == nil {
+		result.formatter = New()
+	}
+
+	return result
+}
+
+// Recover converts recovered panic value to valued error with panic code, scope of formatter,
+// panic value as detail and stack of panic. Error is stored to given error pointer, previous error is replaced.
+// Recover must be deferred directly, e.g. defer errformatter.Recover(&err)...
+func Recover(errPtr *error, opts ...RecoverOption) {
+	value := recover()
+	if value == nil {
+		return
+	}
+
+	options := newRecoverOptions(opts...)
+
+	for i := range options.repanics {
+		if options.repanics[i](value) {
+			panic(value)
+		}
+	}
+
+	// panic error is built with stack of panic, values of formatter are added by re-wrap
+	cause := &panicError{
+		value: nil,
+	}
+
+	if valueErr, isError := value.(error); isError {
+		cause.value = valueErr
+	}
+
+	panicErr := multiValuedErrorOnly(cause, captureStack(true, recoverStackSkip), nil,
+		NewValue(KindDetails, []string{fmt.Sprint(value)}))
+
+	// catalog of formatter doesn't reject codes, which are not registered, so deferred Recover never panics here
+	if errPtr != nil {
+		*errPtr = options.formatter.ErrorWithCode(panicErr, options.code)
+	}
+}
+
+// SafeGo runs function in new goroutine. Panic of function is converted to valued error, see Recover.
+// Errors of function and recovered panics are passed to handler, see WithPanicHandler option...
+func SafeGo(fn func() error, opts ...RecoverOption) {
+	options := newRecoverOptions(opts...)
+
+	go func() {
+		err := safeCall(fn, opts...)
+		if err != nil {
+			options.handler(err)
+		}
+	}()
+}
+
+func safeCall(fn func() error, opts ...RecoverOption) (err error) {
+	defer Recover(&err, opts...)
+
+	return fn()
+}
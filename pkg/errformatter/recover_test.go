@@ -0,0 +1,204 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testRepanicValue struct{}
+
+func panicWithValue(value any) (err error) {
+	defer Recover(&err, WithRecoverFormatter(NewScopedErrorFormatter("signer")),
+		WithRepanicType[testRepanicValue]())
+
+	panic(value)
+}
+
+func TestRecover(t *testing.T) {
+	t.Run("recover - panic value converted to valued error", func(t *testing.T) {
+		const expectedResult = "signer: panic -> boom"
+
+		err := panicWithValue("boom")
+		if err == nil {
+			t.Fatalf("error must be returned")
+		}
+
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s", err.Error(), expectedResult)
+		}
+
+		if code := ValuedErrorGetCode(err); code != CodePanic {
+			t.Errorf("error code not equal with expected. current: %d, expected: %d", code, CodePanic)
+		}
+
+		if !errors.Is(err, ErrPanic) {
+			t.Errorf("error must match ErrPanic sentinel")
+		}
+
+		frames := ErrorStackTrace(err).Frames()
+		if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "panicWithValue") {
+			t.Errorf("stack must start with panic function. current: %+v", frames)
+		}
+	})
+
+	t.Run("recover - panic error is cause of valued error", func(t *testing.T) {
+		errPanicCause := errors.New("nil map write")
+
+		err := panicWithValue(errPanicCause)
+		if !errors.Is(err, errPanicCause) {
+			t.Errorf("error must wrap panic error. current: %s", err)
+		}
+	})
+
+	t.Run("recover - re-panic of matched type and no panic", func(t *testing.T) {
+		var err error
+
+		func() {
+			defer Recover(&err)
+		}()
+
+		if err != nil {
+			t.Errorf("error must be nil without panic. current: %s", err)
+		}
+
+		defer func() {
+			if _, isRepanicked := recover().(testRepanicValue); !isRepanicked {
+				t.Errorf("panic value of matched type must be re-panicked")
+			}
+		}()
+
+		_ = panicWithValue(testRepanicValue{})
+	})
+
+	t.Run("error with panic code - not matched by ErrPanic", func(t *testing.T) {
+		err := NewErrorFormatter().ErrorWithCode(errors.New("test error"), CodePanic)
+		if errors.Is(err, ErrPanic) {
+			t.Errorf("error, which is not built from panic value, must not match ErrPanic")
+		}
+
+		if err = panicWithValue("boom"); !errors.Is(ValuedErrorOnly(err, NewValue(KindCode, 500)), ErrPanic) {
+			t.Errorf("error of panic value with other code must match ErrPanic")
+		}
+	})
+
+	t.Run("formatter with catalog - panic code is attached", func(t *testing.T) {
+		catalog := NewCatalog().MustRegister(CodeInfo{
+			Code:        500,
+			Name:        "recover_internal",
+			Description: "",
+			PublicCode:  1500,
+			HTTPStatus:  0,
+			GRPCCode:    0,
+			Severity:    SeverityCritical,
+		})
+
+		recoverWithCode := func(opts ...RecoverOption) (err error) {
+			defer Recover(&err, append(opts, WithRecoverFormatter(New(WithCatalog(catalog))))...)
+
+			panic("boom")
+		}
+
+		err := recoverWithCode()
+		if code := ValuedErrorGetCode(err); code != CodePanic || !errors.Is(err, ErrPanic) {
+			t.Errorf("error code not equal with expected. current: %d, expected: %d", code, CodePanic)
+		}
+
+		err = recoverWithCode(WithPanicCode(500))
+		if publicCode := ValuedErrorGetPublicCode(err); publicCode != 1500 {
+			t.Errorf("public code not equal with expected. current: %d, expected: %d", publicCode, 1500)
+		}
+
+		if severity := ErrorSeverity(err); severity != SeverityCritical {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s", severity, SeverityCritical)
+		}
+	})
+
+	t.Run("nil panic handler - default handler is kept", func(t *testing.T) {
+		if options := newRecoverOptions(WithPanicHandler(nil)); options.handler == nil {
+			t.Errorf("handler must be not nil")
+		}
+	})
+
+	t.Run("safe go - errors and panics are passed to handler", func(t *testing.T) {
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			handled []error
+		)
+
+		handler := WithPanicHandler(func(err error) {
+			mu.Lock()
+			handled = append(handled, err)
+			mu.Unlock()
+
+			wg.Done()
+		})
+
+		wg.Add(2)
+
+		SafeGo(func() error {
+			panic("boom")
+		}, handler, WithPanicCode(500))
+		SafeGo(func() error {
+			return errors.New("worker error")
+		}, handler)
+		SafeGo(func() error {
+			return nil
+		}, handler)
+
+		wg.Wait()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(handled) != 2 {
+			t.Fatalf("handled errors count not equal with expected. current: %d, expected: %d", len(handled), 2)
+		}
+
+		var panicErr error
+
+		for i := range handled {
+			if ValuedErrorGetCode(handled[i]) == 500 {
+				panicErr = handled[i]
+			}
+		}
+
+		if panicErr == nil || panicErr.Error() != "panic -> boom" {
+			t.Errorf("panic error not equal with expected. current: %v", panicErr)
+		}
+	})
+}
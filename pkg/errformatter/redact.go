@@ -0,0 +1,249 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	_ "embed"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// RedactedText - replacement of sensitive data in error text and serialized errors...
+	RedactedText = "[REDACTED]"
+	// minMnemonicWordsCount - min count of words of BIP-39 mnemonic phrase
+	minMnemonicWordsCount = 12
+)
+
+// Redactor - detector of sensitive data, returns text with replaced sensitive data...
+type Redactor func(text string) string
+
+//nolint:gochecknoglobals // it's ok - built-in detectors are compiled once
+var (
+	// hexKeyPattern - hex encoded 32-byte keys, e.g. private keys of secp256k1 and ed25519 curves
+	hexKeyPattern = regexp.MustCompile(`\b(?:0x)?[0-9a-fA-F]{64}\b`)
+	// mnemonicCandidatePattern - sequences of 12 or more lowercase words, candidates of BIP-39 mnemonic phrases
+	mnemonicCandidatePattern = regexp.MustCompile(`\b[a-z]{3,8}(?:\s+[a-z]{3,8}){11,}\b`)
+	// wordPattern - lowercase word of mnemonic candidate
+	wordPattern = regexp.MustCompile(`[a-z]+`)
+	// wifKeyPattern - base58 encoded private keys in wallet import format, mainnet and testnet
+	wifKeyPattern = regexp.MustCompile(
+		`\b(?:[59][1-9A-HJ-NP-Za-km-z]{50}|[KLc][1-9A-HJ-NP-Za-km-z]{51})\b`)
+	// extendedKeyPattern - BIP-32 extended public and private keys - xpub, xprv, tpub, ypub, zpub...
+	extendedKeyPattern = regexp.MustCompile(`\b[xyzXYZtuv](?:pub|prv)[1-9A-HJ-NP-Za-km-z]{100,112}\b`)
+	// bearerTokenPattern - bearer tokens of authorization headers, token type is kept
+	bearerTokenPattern = regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	// jwtPattern - JSON web tokens
+	jwtPattern = regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`)
+)
+
+//nolint:gochecknoglobals // it's ok - package-level switch of redaction
+var isRedactionDisabled atomic.Bool
+
+// SetRedaction enables or disables redaction of sensitive data. Redaction is enabled by default...
+func SetRedaction(isEnabled bool) {
+	isRedactionDisabled.Store(!isEnabled)
+}
+
+// IsRedactionEnabled returns state of package-level redaction switch...
+func IsRedactionEnabled() bool {
+	return !isRedactionDisabled.Load()
+}
+
+type redactorRegistry struct {
+	mu sync.RWMutex
+
+	redactors []Redactor
+}
+
+//nolint:gochecknoglobals // it's ok - registry of redactors must be shared by all formatters
+var redactors = &redactorRegistry{
+	mu: sync.RWMutex{},
+	redactors: []Redactor{
+		NewPatternRedactor(extendedKeyPattern),
+		NewPatternRedactor(jwtPattern),
+		func(text string) string {
+			return bearerTokenPattern.ReplaceAllString(text, "${1}"+RedactedText)
+		},
+		NewPatternRedactor(hexKeyPattern),
+		NewPatternRedactor(wifKeyPattern),
+		redactMnemonics,
+	},
+}
+
+// bip39EnglishWords - english wordlist of BIP-39 mnemonic phrases, 2048 words
+//
+//go:embed bip39_english.txt
+var bip39EnglishWords string
+
+//nolint:gochecknoglobals // it's ok - wordlist is built once
+var bip39Words = func() map[string]struct{} {
+	words := strings.Fields(bip39EnglishWords)
+
+	result := make(map[string]struct{}, len(words))
+	for i := range words {
+		result[words[i]] = struct{}{}
+	}
+
+	return result
+}()
+
+// redactMnemonics replaces runs of 12 or more consecutive words of BIP-39 wordlist by RedactedText.
+// Ordinary text, which contains words not from wordlist, is kept as is...
+func redactMnemonics(text string) string {
+	return mnemonicCandidatePattern.ReplaceAllStringFunc(text, func(candidate string) string {
+		var (
+			builder   strings.Builder
+			written   int
+			runStart  int
+			runLength int
+		)
+
+		flush := func(runEnd int) {
+			if runLength >= minMnemonicWordsCount {
+				builder.WriteString(candidate[written:runStart])
+				builder.WriteString(RedactedText)
+				written = runEnd
+			}
+
+			runLength = 0
+		}
+
+		words := wordPattern.FindAllStringIndex(candidate, -1)
+		for i := range words {
+			if _, isWord := bip39Words[candidate[words[i][0]:words[i][1]]]; !isWord {
+				if i > 0 {
+					flush(words[i-1][1])
+				}
+
+				continue
+			}
+
+			if runLength == 0 {
+				runStart = words[i][0]
+			}
+
+			runLength++
+		}
+
+		flush(len(candidate))
+
+		builder.WriteString(candidate[written:])
+
+		return builder.String()
+	})
+}
+
+func (r *redactorRegistry) register(redactor Redactor) {
+	if redactor == nil {
+		panic("errfmt: redactor must be not nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.redactors = append(r.redactors, redactor)
+}
+
+func (r *redactorRegistry) redact(text string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := range r.redactors {
+		text = r.redactors[i](text)
+	}
+
+	return text
+}
+
+// RegisterRedactor registers detector of sensitive data in addition to built-in detectors -
+// hex keys, BIP-39 mnemonics, WIF keys, BIP-32 extended keys, bearer tokens and JWTs...
+func RegisterRedactor(redactor Redactor) {
+	redactors.register(redactor)
+}
+
+// NewPatternRedactor returns Redactor, which replaces all matches of given pattern by RedactedText...
+func NewPatternRedactor(pattern *regexp.Regexp) Redactor {
+	return func(text string) string {
+		return pattern.ReplaceAllLiteralString(text, RedactedText)
+	}
+}
+
+// Redact returns text with sensitive data replaced by RedactedText, text returned as is if redaction is disabled...
+func Redact(text string) string {
+	if !IsRedactionEnabled() || text == "" {
+		return text
+	}
+
+	return redactors.redact(text)
+}
+
+func redactDetails(details []string) []string {
+	if !IsRedactionEnabled() || len(details) == 0 {
+		return details
+	}
+
+	result := make([]string, len(details))
+	for i := range details {
+		result[i] = redactors.redact(details[i])
+	}
+
+	return result
+}
+
+// Secret - wrapper of sensitive format args and values, always rendered as RedactedText...
+type Secret string
+
+// String implements fmt.Stringer interface...
+func (s Secret) String() string {
+	return RedactedText
+}
+
+// Format implements fmt.Formatter interface, all verbs render RedactedText...
+func (s Secret) Format(state fmt.State, _ rune) {
+	_, _ = state.Write([]byte(RedactedText))
+}
+
+// MarshalJSON implements json.Marshaler interface...
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + RedactedText + `"`), nil
+}
+
+// LogValue implements slog.LogValuer interface...
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(RedactedText)
+}
@@ -0,0 +1,219 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"context"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Retry - retry classification of error, value of KindRetry...
+type Retry struct {
+	// IsRetryable - operation, which returned error, can be retried
+	IsRetryable bool `json:"retryable"`
+	// After - min delay before retry, zero if delay is not set
+	After time.Duration `json:"after,omitempty"`
+}
+
+// String returns text of retry classification - retryable, non-retryable or retryable after delay...
+func (r Retry) String() string {
+	switch {
+	case !r.IsRetryable:
+		return "non-retryable"
+	case r.After > 0:
+		return "retryable after " + r.After.String()
+	default:
+		return "retryable"
+	}
+}
+
+// NewRetryValue returns Value of KindRetry - retryable or non-retryable classification...
+func NewRetryValue(isRetryable bool) Value {
+	return NewValue(KindRetry, Retry{
+		IsRetryable: isRetryable,
+		After:       0,
+	})
+}
+
+// NewRetryAfterValue returns Value of KindRetry - retryable classification with min delay before retry...
+func NewRetryAfterValue(after time.Duration) Value {
+	return NewValue(KindRetry, Retry{
+		IsRetryable: true,
+		After:       after,
+	})
+}
+
+// RetryClassifier - classifier of errors, which have no retry classification value. Classifier is called
+// for each error of cause chain, returns false if error is unknown for classifier...
+type RetryClassifier func(err error) (Retry, bool)
+
+type retryClassifierRegistry struct {
+	mu sync.RWMutex
+
+	classifiers []RetryClassifier
+}
+
+//nolint:gochecknoglobals // it's ok - registry of retry classifiers must be shared by all formatters
+var retryClassifiers = &retryClassifierRegistry{
+	mu: sync.RWMutex{},
+	classifiers: []RetryClassifier{
+		classifyDeadlineExceeded,
+		classifyNetTimeout,
+		classifyConnectionReset,
+	},
+}
+
+func (r *retryClassifierRegistry) register(classifier RetryClassifier) {
+	if classifier == nil {
+		panic("errfmt: retry classifier must be not nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.classifiers = append(r.classifiers, classifier)
+}
+
+func (r *retryClassifierRegistry) classify(err error) (Retry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := range r.classifiers {
+		if retry, isClassified := r.classifiers[i](err); isClassified {
+			return retry, true
+		}
+	}
+
+	return Retry{IsRetryable: false, After: 0}, false
+}
+
+// RegisterRetryClassifier registers classifier of errors in addition to built-in classifiers -
+// context.DeadlineExceeded, timeouts of net.Error and syscall.ECONNRESET errors are retryable...
+func RegisterRetryClassifier(classifier RetryClassifier) {
+	retryClassifiers.register(classifier)
+}
+
+// ClassifyRetry walks by cause chain of error and returns first retry classification - value of KindRetry
+// or result of retry classifiers. Returns false if no errors of chain are classified...
+func ClassifyRetry(err error) (Retry, bool) {
+	queue := []error{err}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == nil {
+			continue
+		}
+
+		if retry, isClassified := retryOf(current); isClassified {
+			return retry, true
+		}
+
+		//nolint:errorlint // it's ok - here we need to check direct implementation of Unwrap
+		switch unwrapper := current.(type) {
+		case interface{ Unwrap() []error }:
+			queue = append(queue, unwrapper.Unwrap()...)
+		case interface{ Unwrap() error }:
+			queue = append(queue, unwrapper.Unwrap())
+		}
+	}
+
+	return Retry{IsRetryable: false, After: 0}, false
+}
+
+// IsRetryable reports whether operation, which returned error, can be retried...
+func IsRetryable(err error) bool {
+	retry, _ := ClassifyRetry(err)
+
+	return retry.IsRetryable
+}
+
+// RetryAfter returns min delay before retry. Returns false if error is not retryable or delay is not set...
+func RetryAfter(err error) (time.Duration, bool) {
+	retry, _ := ClassifyRetry(err)
+	if !retry.IsRetryable || retry.After <= 0 {
+		return 0, false
+	}
+
+	return retry.After, true
+}
+
+// retryOf returns retry classification of one error of cause chain, without unwrapping...
+func retryOf(err error) (Retry, bool) {
+	//nolint:errorlint // it's ok - each error of chain is checked separately
+	switch typedErr := err.(type) {
+	case *valuedError:
+		if typedErr.settled.Has(ValueRetryIsSet) {
+			return typedErr.values[KindRetry].getRetry(), true
+		}
+	case *multiError:
+		if typedErr.valued.settled.Has(ValueRetryIsSet) {
+			return typedErr.valued.values[KindRetry].getRetry(), true
+		}
+	}
+
+	return retryClassifiers.classify(err)
+}
+
+func classifyDeadlineExceeded(err error) (Retry, bool) {
+	//nolint:errorlint // it's ok - each error of chain is checked separately
+	if err != context.DeadlineExceeded {
+		return Retry{IsRetryable: false, After: 0}, false
+	}
+
+	return Retry{IsRetryable: true, After: 0}, true
+}
+
+func classifyNetTimeout(err error) (Retry, bool) {
+	//nolint:errorlint // it's ok - each error of chain is checked separately
+	netErr, isNetErr := err.(net.Error)
+	if !isNetErr || !netErr.Timeout() {
+		return Retry{IsRetryable: false, After: 0}, false
+	}
+
+	return Retry{IsRetryable: true, After: 0}, true
+}
+
+func classifyConnectionReset(err error) (Retry, bool) {
+	//nolint:errorlint // it's ok - each error of chain is checked separately
+	errno, isErrno := err.(syscall.Errno)
+	if !isErrno || errno != syscall.ECONNRESET {
+		return Retry{IsRetryable: false, After: 0}, false
+	}
+
+	return Retry{IsRetryable: true, After: 0}, true
+}
@@ -0,0 +1,150 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryClassification(t *testing.T) {
+	t.Run("formatter services - retry classification values", func(t *testing.T) {
+		errForWrap := errors.New("node is unavailable")
+
+		services := map[string]Formatter{
+			"formatter":                  NewErrorFormatter(),
+			"scoped formatter":           NewScopedErrorFormatter("poller"),
+			"valued formatter":           NewValuesErrorFormatter(),
+			"valued formatter, defaults": NewValuesErrorFormatter(NewValue(KindScope, "poller")),
+		}
+
+		for name, svc := range services {
+			if !IsRetryable(svc.ErrorWithRetry(errForWrap, true)) {
+				t.Errorf("%s: error must be retryable", name)
+			}
+
+			if IsRetryable(svc.ErrWithRetry(errForWrap, false)) {
+				t.Errorf("%s: error must be non-retryable", name)
+			}
+
+			after, isSet := RetryAfter(svc.ErrorWithRetryAfter(errForWrap, time.Second))
+			if !isSet || after != time.Second {
+				t.Errorf("%s: retry delay not equal with expected. current: %s, expected: %s",
+					name, after, time.Second)
+			}
+		}
+	})
+
+	t.Run("valued error - classification kept on re-wrap, outer classification wins", func(t *testing.T) {
+		const expectedResult = "poller: fetch block -> height 42"
+
+		svc := NewValuesErrorFormatter(NewValue(KindScope, "poller"))
+
+		err := svc.Errorf(svc.ErrorWithRetryAfter(errors.New("fetch block"), 5*time.Second), "height %d", 42)
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		if after, _ := RetryAfter(err); after != 5*time.Second {
+			t.Errorf("retry delay not equal with expected. current: %s, expected: %s",
+				after, 5*time.Second)
+		}
+
+		nonRetryableErr := svc.ErrorWithRetry(fmt.Errorf("rpc call: %w", context.DeadlineExceeded), false)
+		if IsRetryable(nonRetryableErr) {
+			t.Errorf("explicit classification must have priority over default classifiers")
+		}
+	})
+
+	t.Run("default classifiers", func(t *testing.T) {
+		testCases := map[string]error{
+			"deadline exceeded": fmt.Errorf("rpc call: %w", context.DeadlineExceeded),
+			"net timeout": NewErrorFormatter().Error(&net.DNSError{
+				Err:       "i/o timeout",
+				Name:      "node.local",
+				IsTimeout: true,
+			}, "resolve node"),
+			"connection reset": &os.SyscallError{Syscall: "read", Err: syscall.ECONNRESET},
+		}
+
+		for name, err := range testCases {
+			if !IsRetryable(err) {
+				t.Errorf("%s: error must be retryable", name)
+			}
+		}
+
+		if IsRetryable(errors.New("invalid signature")) {
+			t.Errorf("unknown error must be non-retryable")
+		}
+	})
+
+	t.Run("custom classifier", func(t *testing.T) {
+		errRateLimited := errors.New("rate limited")
+
+		RegisterRetryClassifier(func(err error) (Retry, bool) {
+			//nolint:errorlint // it's ok - each error of chain is checked separately
+			if err != errRateLimited {
+				return Retry{IsRetryable: false, After: 0}, false
+			}
+
+			return Retry{IsRetryable: true, After: time.Minute}, true
+		})
+
+		if after, _ := RetryAfter(ScopedError(errRateLimited, "poller")); after != time.Minute {
+			t.Errorf("retry delay not equal with expected. current: %s, expected: %s",
+				after, time.Minute)
+		}
+	})
+
+	t.Run("valued error - retry classification in json", func(t *testing.T) {
+		err := NewValuesErrorFormatter().ErrorWithRetryAfter(errors.New("fetch block"), time.Second)
+
+		data, marshalErr := json.Marshal(err)
+		if marshalErr != nil {
+			t.Fatalf("unable to marshal error: %s", marshalErr)
+		}
+
+		if after, _ := RetryAfter(DecodeError(data)); after != time.Second {
+			t.Errorf("retry delay not equal with expected. current: %s, expected: %s",
+				after, time.Second)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"strings"
+)
+
+// JoinScope returns scope path of child scope, e.g. JoinScope("wallet/signer", "ecdsa") is wallet/signer/ecdsa...
+func JoinScope(parent string, child string) string {
+	parent = strings.Trim(parent, ScopeSeparator)
+	child = strings.Trim(child, ScopeSeparator)
+
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	default:
+		return parent + ScopeSeparator + child
+	}
+}
+
+// ScopePath returns segments of scope path of error origin. Scope path of origin is kept
+// on re-wrap by formatters with other scopes, so it can be used for routing and alerting...
+func ScopePath(err error) []string {
+	scope := originScope(err)
+	if scope == "" {
+		return nil
+	}
+
+	return splitScope(scope)
+}
+
+// HasScopePrefix reports whether scope path of error origin starts with given scope path prefix.
+// Prefix is compared by whole segments - wallet/sign is not prefix of wallet/signer/ecdsa...
+func HasScopePrefix(err error, prefix string) bool {
+	path := ScopePath(err)
+	if path == nil {
+		return false
+	}
+
+	prefixPath := splitScope(prefix)
+	if len(prefixPath) > len(path) {
+		return false
+	}
+
+	for i := range prefixPath {
+		if path[i] != prefixPath[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// originScope returns scope of deepest scoped or valued error of cause chain...
+func originScope(err error) string {
+	var scope string
+
+	for current := err; current != nil; current = errors.Unwrap(current) {
+		//nolint:errorlint // it's ok - each error of cause chain is checked separately
+		switch typedErr := current.(type) {
+		case *valuedError:
+			if typedErr.scopePath != "" {
+				scope = typedErr.scopePath
+			}
+		case *scopedError:
+			scope = typedErr.scope
+		}
+	}
+
+	return scope
+}
+
+func splitScope(scope string) []string {
+	scope = strings.Trim(scope, ScopeSeparator)
+	if scope == "" {
+		return nil
+	}
+
+	return strings.Split(scope, ScopeSeparator)
+}
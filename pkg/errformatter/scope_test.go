@@ -0,0 +1,155 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestScopeHierarchy(t *testing.T) {
+	t.Run("join scope - segments joined by separator", func(t *testing.T) {
+		const expectedResult = "wallet/signer/ecdsa"
+
+		if scope := JoinScope("wallet/signer/", "/ecdsa"); scope != expectedResult {
+			t.Errorf("scope not equal with expected. current: %s, expected: %s",
+				scope, expectedResult)
+		}
+
+		if scope := JoinScope("", expectedResult); scope != expectedResult {
+			t.Errorf("scope not equal with expected. current: %s, expected: %s",
+				scope, expectedResult)
+		}
+	})
+
+	t.Run("scoped formatter - child formatters", func(t *testing.T) {
+		const expectedResult = "wallet/signer/ecdsa: invalid signature"
+
+		svc := NewScopedErrorFormatter("wallet").WithScope("signer").WithScope("ecdsa")
+
+		err := svc.NewError("invalid signature")
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		expectedPath := []string{"wallet", "signer", "ecdsa"}
+		if path := ScopePath(err); !slices.Equal(path, expectedPath) {
+			t.Errorf("scope path not equal with expected. current: %s, expected: %s",
+				path, expectedPath)
+		}
+	})
+
+	t.Run("valued formatter - child formatters keep default values", func(t *testing.T) {
+		const (
+			expectedResult = "wallet/signer/ecdsa: invalid signature -> key_id"
+			expectedCode   = 4001
+		)
+
+		svc := NewValuesErrorFormatter(
+			NewValue(KindScope, "wallet"),
+			NewValue(KindCode, expectedCode),
+		).WithScope("signer/ecdsa")
+
+		err := svc.Error(errors.New("invalid signature"), "key_id")
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		if code := ValuedErrorGetCode(err); code != expectedCode {
+			t.Errorf("error code not equal with expected. current: %d, expected: %d",
+				code, expectedCode)
+		}
+
+		childErr := NewValuesErrorFormatter().WithScope("wallet").NewError("child error")
+		if !HasScopePrefix(childErr, "wallet") {
+			t.Errorf("error must have scope prefix. current: %s, expected: %s",
+				ScopePath(childErr), "wallet")
+		}
+	})
+
+	t.Run("valued error - scope path of origin kept on re-wrap by other scope", func(t *testing.T) {
+		const expectedResult = "wallet/api: wallet/signer/ecdsa: invalid signature"
+
+		signerSvc := NewErrorFormatter().WithScope("wallet/signer/ecdsa")
+		apiSvc := NewValuesErrorFormatter(NewValue(KindScope, "wallet/api"))
+
+		err := apiSvc.ErrorWithCode(signerSvc.ErrorWithCode(errors.New("invalid signature"), 3001), 5001)
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		expectedPath := []string{"wallet", "signer", "ecdsa"}
+		if path := ScopePath(err); !slices.Equal(path, expectedPath) {
+			t.Errorf("scope path not equal with expected. current: %s, expected: %s",
+				path, expectedPath)
+		}
+
+		if !HasScopePrefix(err, "wallet/signer") {
+			t.Errorf("error must have scope prefix. current: %s, expected: %s",
+				strings.Join(ScopePath(err), ScopeSeparator), "wallet/signer")
+		}
+
+		if HasScopePrefix(err, "wallet/sign") {
+			t.Errorf("scope prefix must be compared by whole segments. current: %s",
+				strings.Join(ScopePath(err), ScopeSeparator))
+		}
+
+		if HasScopePrefix(errors.New("not scoped"), "wallet") {
+			t.Errorf("not scoped error must not have scope prefix")
+		}
+	})
+
+	t.Run("valued error - scope path kept in json", func(t *testing.T) {
+		err := NewValuesErrorFormatter(NewValue(KindScope, "wallet/api")).
+			Error(NewValuesErrorFormatter(NewValue(KindScope, "wallet/signer")).NewError("origin"))
+
+		data, marshalErr := json.Marshal(err)
+		if marshalErr != nil {
+			t.Fatalf("unable to marshal error: %s", marshalErr)
+		}
+
+		decodedErr := DecodeError(data)
+
+		expectedPath := []string{"wallet", "signer"}
+		if path := ScopePath(decodedErr); !slices.Equal(path, expectedPath) {
+			t.Errorf("scope path not equal with expected. current: %s, expected: %s",
+				path, expectedPath)
+		}
+	})
+}
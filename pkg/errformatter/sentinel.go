@@ -0,0 +1,71 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+// Define returns sentinel valued error with given scope, code and message. Any valued error with same scope
+// and code matches sentinel by errors.Is, even if error was restored from json or gRPC status
+// and identity of sentinel error is lost. Sentinel with empty scope is matched only by code...
+func Define(scope string, code int, message string) *valuedError {
+	if code <= 0 {
+		panic("errfmt: code must be positive value")
+	}
+
+	values := make([]Value, 0, 2) //nolint:mnd // it's ok - scope and code values
+	if scope != "" {
+		values = append(values, NewValue(KindScope, scope))
+	}
+
+	values = append(values, NewValue(KindCode, code))
+
+	sentinelErr := valuedNewError(nil, nil, values, message)
+	sentinelErr.isSentinel = true
+
+	return sentinelErr
+}
+
+// matchSentinel reports whether error node has same scope and code with sentinel error.
+// Scope of error origin is compared too, so sentinel is matched after re-wrap by formatters with other scopes...
+func (e *valuedError) matchSentinel(sentinel *valuedError) bool {
+	if !e.settled.Has(ValueCodeIsSet) || e.values[KindCode].getCode() != sentinel.values[KindCode].getCode() {
+		return false
+	}
+
+	if !sentinel.settled.Has(ValueScopeIsSet) {
+		return true
+	}
+
+	scope := sentinel.values[KindScope].getScope()
+
+	return e.scopePath == scope ||
+		(e.settled.Has(ValueScopeIsSet) && e.values[KindScope].getScope() == scope)
+}
@@ -0,0 +1,127 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestDefine(t *testing.T) {
+	errWalletNotFound := Define("wallet", 404, "wallet not found")
+
+	t.Run("sentinel - text and values", func(t *testing.T) {
+		const expectedResult = "wallet: wallet not found"
+
+		if errWalletNotFound.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				errWalletNotFound.Error(), expectedResult)
+		}
+
+		if code := ValuedErrorGetCode(errWalletNotFound); code != 404 {
+			t.Errorf("code not equal with expected. current: %d, expected: %d", code, 404)
+		}
+	})
+
+	t.Run("sentinel - matched by scope and code", func(t *testing.T) {
+		testCases := map[string]error{
+			"wrapped sentinel": NewErrorFormatter().Error(errWalletNotFound, "id 42"),
+			"fmt wrapped":      fmt.Errorf("outer: %w", errWalletNotFound),
+			"re-wrapped scope": NewScopedErrorFormatter("api").ErrorWithCode(errWalletNotFound, 400),
+			"same scope & code": NewValuesErrorFormatter(NewValue(KindScope, "wallet")).
+				ErrorWithCode(errors.New("storage miss"), 404),
+			"restored error": RestoreValuedError("wallet: wallet not found", nil,
+				NewValue(KindScope, "wallet"), NewValue(KindCode, 404)),
+		}
+
+		for name, err := range testCases {
+			if !errors.Is(err, errWalletNotFound) {
+				t.Errorf("%s: error must match sentinel. current: %s", name, err)
+			}
+		}
+	})
+
+	t.Run("sentinel - not matched by other scope or code", func(t *testing.T) {
+		testCases := map[string]error{
+			"other code": NewValuesErrorFormatter(NewValue(KindScope, "wallet")).
+				ErrorWithCode(errors.New("storage miss"), 500),
+			"other scope": NewValuesErrorFormatter(NewValue(KindScope, "signer")).
+				ErrorWithCode(errors.New("storage miss"), 404),
+			"without code": NewScopedErrorFormatter("wallet").Error(errors.New("storage miss")),
+		}
+
+		for name, err := range testCases {
+			if errors.Is(err, errWalletNotFound) {
+				t.Errorf("%s: error must not match sentinel. current: %s", name, err)
+			}
+		}
+
+		// wrapped sentinel is not sentinel itself, valued errors are matched only by identity
+		wrappedErr := NewErrorFormatter().Error(errWalletNotFound, "id 42")
+		if errors.Is(NewErrorFormatter().ErrorWithCode(errors.New("storage miss"), 404), wrappedErr) {
+			t.Errorf("error must not match wrapped sentinel")
+		}
+	})
+
+	t.Run("sentinel - matched after json round trip", func(t *testing.T) {
+		err := NewValuesErrorFormatter(NewValue(KindScope, "api")).Error(errWalletNotFound, "id 42")
+
+		rawErr, marshalErr := json.Marshal(err)
+		if marshalErr != nil {
+			t.Fatalf("unable to marshal error: %v", marshalErr)
+		}
+
+		if decodedErr := DecodeError(rawErr); !errors.Is(decodedErr, errWalletNotFound) {
+			t.Errorf("decoded error must match sentinel. current: %s", decodedErr)
+		}
+	})
+
+	t.Run("sentinel without scope - matched only by code", func(t *testing.T) {
+		errNotFound := Define("", 404, "not found")
+
+		err := NewScopedErrorFormatter("signer").ErrorWithCode(errors.New("storage miss"), 404)
+		if !errors.Is(err, errNotFound) {
+			t.Errorf("error must match sentinel. current: %s", err)
+		}
+
+		defer func() {
+			if recover() == nil {
+				t.Errorf("not positive code must panic")
+			}
+		}()
+
+		_ = Define("wallet", 0, "invalid")
+	})
+}
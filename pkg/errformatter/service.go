@@ -32,10 +32,16 @@
 
 package errformatter
 
-var _ selfService = (*service)(nil)
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var _ Formatter = (*service)(nil)
 
-//nolint:gofumpt
 type service struct {
+	options options
 }
 
 func (s *service) ErrGetCode(err error) int {
@@ -55,7 +61,72 @@ func (s *service) ErrorWithCode(err error, code int) error {
 		panic("errfmt: code must be positive value")
 	}
 
-	return ValuedErrorOnly(err, NewValue(KindCode, code))
+	catalogValues := s.options.catalogValues(code)
+
+	vErr := valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewValue(KindCode, code))
+	if vErr != nil {
+		// public code and severity of code are not part of error text, so they are set to same error node
+		_ = vErr.setValues(catalogValues...)
+	}
+
+	return observed(vErr)
+}
+
+func (s *service) ErrGetPublicCode(err error) int {
+	return s.ErrorGetPublicCode(err)
+}
+
+func (s *service) ErrorGetPublicCode(err error) int {
+	return ValuedErrorGetPublicCode(err)
+}
+
+func (s *service) ErrWithPublicCode(err error, publicCode int) error {
+	return s.ErrorWithPublicCode(err, publicCode)
+}
+
+func (s *service) ErrorWithPublicCode(err error, publicCode int) error {
+	if publicCode <= 0 {
+		panic("errfmt: public code must be positive value")
+	}
+
+	return observed(valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewValue(KindPublicCode, publicCode)))
+}
+
+func (s *service) ErrWithRetry(err error, isRetryable bool) error {
+	return s.ErrorWithRetry(err, isRetryable)
+}
+
+func (s *service) ErrorWithRetry(err error, isRetryable bool) error {
+	return observed(valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewRetryValue(isRetryable)))
+}
+
+func (s *service) ErrWithRetryAfter(err error, after time.Duration) error {
+	return s.ErrorWithRetryAfter(err, after)
+}
+
+func (s *service) ErrorWithRetryAfter(err error, after time.Duration) error {
+	if after <= 0 {
+		panic("errfmt: retry delay must be positive value")
+	}
+
+	return observed(valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewRetryAfterValue(after)))
+}
+
+func (s *service) ErrWithSeverity(err error, severity Severity) error {
+	return s.ErrorWithSeverity(err, severity)
+}
+
+func (s *service) ErrorWithSeverity(err error, severity Severity) error {
+	if severity == SeverityUnknown {
+		panic("errfmt: severity must be known value")
+	}
+
+	return observed(valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewSeverityValue(severity)))
 }
 
 func (s *service) ErrNoWrap(err error) error {
@@ -67,25 +138,106 @@ func (s *service) ErrorNoWrap(err error) error {
 }
 
 func (s *service) ErrorOnly(err error, details ...string) error {
-	return ErrorOnly(err, details...)
+	return s.errorWithValues(err, captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.severityValues(), details...)
 }
 
 func (s *service) Error(err error, details ...string) error {
-	return Error(err, details...)
+	return s.errorWithValues(err, captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.severityValues(), details...)
 }
 
 func (s *service) Errorf(err error, format string, args ...interface{}) error {
-	return Errorf(err, format, args...)
+	return s.errorfWithValues(err, captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.severityValues(), format, args...)
 }
 
 func (s *service) NewError(details ...string) error {
-	return NewError(details...)
+	return s.newErrorWithValues(captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.severityValues(), details...)
 }
 
 func (s *service) NewErrorf(format string, args ...interface{}) error {
-	return NewErrorf(format, args...)
+	return s.newErrorfWithValues(captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.severityValues(), format, args...)
+}
+
+func (s *service) ErrorCtx(ctx context.Context, err error, details ...string) error {
+	return s.errorWithValues(err, captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.valuesWith(ContextValues(ctx)), details...)
+}
+
+func (s *service) ErrorfCtx(ctx context.Context, err error, format string, args ...interface{}) error {
+	return s.errorfWithValues(err, captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.valuesWith(ContextValues(ctx)), format, args...)
+}
+
+func (s *service) NewErrorCtx(ctx context.Context, details ...string) error {
+	return s.newErrorWithValues(captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.valuesWith(ContextValues(ctx)), details...)
+}
+
+func (s *service) NewErrorfCtx(ctx context.Context, format string, args ...interface{}) error {
+	return s.newErrorfWithValues(captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.valuesWith(ContextValues(ctx)), format, args...)
+}
+
+// errorWithValues returns formatted error or valued error, if values list is not empty or stack is captured...
+func (s *service) errorWithValues(err error, stack Stack, values []Value, details ...string) error {
+	// nil error is not wrapped by values, so typed nil of valued error is not returned as non-nil error
+	if err == nil {
+		return nil
+	}
+
+	if len(values) == 0 && len(stack) == 0 {
+		return formattedErrorOnly(err, s.options.layout, details...)
+	}
+
+	return observed(multiValuedErrorOnly(err, stack, s.options.layout, append(values, NewValue(KindDetails, details))...))
+}
+
+func (s *service) errorfWithValues(err error, stack Stack, values []Value,
+	format string, args ...interface{},
+) error {
+	if err == nil {
+		return nil
+	}
+
+	if len(values) == 0 && len(stack) == 0 {
+		return formattedErrorOnly(err, s.options.layout, fmt.Sprintf(format, args...))
+	}
+
+	return observed(valuedErrorf(err, stack, s.options.layout, values, format, args...))
+}
+
+func (s *service) newErrorWithValues(stack Stack, values []Value, details ...string) error {
+	if len(values) == 0 && len(stack) == 0 {
+		return newError(s.options.layout, details...)
+	}
+
+	return observed(valuedNewError(stack, s.options.layout, values, details...))
+}
+
+func (s *service) newErrorfWithValues(stack Stack, values []Value, format string, args ...interface{}) error {
+	if len(values) == 0 && len(stack) == 0 {
+		return newError(s.options.layout, fmt.Sprintf(format, args...))
+	}
+
+	return observed(valuedNewErrorf(stack, s.options.layout, values, format, args...))
+}
+
+func (s *service) WithScope(scope string) Formatter {
+	if scope == "" {
+		panic("errfmt: scope must be not empty")
+	}
+
+	return &serviceScoped{
+		scope:   JoinScope("", scope),
+		options: s.options,
+	}
 }
 
-func NewErrorFormatter() *service {
-	return &service{}
+// NewErrorFormatter returns formatter service in plain mode, same with New function...
+func NewErrorFormatter(opts ...Option) Formatter {
+	return New(opts...)
 }
@@ -32,10 +32,18 @@
 
 package errformatter
 
-var _ selfService = (*serviceScoped)(nil)
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var _ Formatter = (*serviceScoped)(nil)
 
 type serviceScoped struct {
-	scope string
+	scope   string
+	options options
 }
 
 func (s *serviceScoped) ErrGetCode(err error) int {
@@ -55,9 +63,73 @@ func (s *serviceScoped) ErrorWithCode(err error, code int) error {
 		panic("errfmt: code must be positive value")
 	}
 
-	return MultiValuedErrorOnly(err,
+	values := append([]Value{
 		NewValue(KindCode, code),
-		NewValue(KindScope, s.scope))
+		NewValue(KindScope, s.scope),
+	}, s.options.catalogValues(code)...)
+
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		values...))
+}
+
+func (s *serviceScoped) ErrGetPublicCode(err error) int {
+	return s.ErrorGetPublicCode(err)
+}
+
+func (s *serviceScoped) ErrorGetPublicCode(err error) int {
+	return ValuedErrorGetPublicCode(err)
+}
+
+func (s *serviceScoped) ErrWithPublicCode(err error, publicCode int) error {
+	return s.ErrorWithPublicCode(err, publicCode)
+}
+
+func (s *serviceScoped) ErrorWithPublicCode(err error, publicCode int) error {
+	if publicCode <= 0 {
+		panic("errfmt: public code must be positive value")
+	}
+
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewValue(KindPublicCode, publicCode),
+		NewValue(KindScope, s.scope)))
+}
+
+func (s *serviceScoped) ErrWithRetry(err error, isRetryable bool) error {
+	return s.ErrorWithRetry(err, isRetryable)
+}
+
+func (s *serviceScoped) ErrorWithRetry(err error, isRetryable bool) error {
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewRetryValue(isRetryable),
+		NewValue(KindScope, s.scope)))
+}
+
+func (s *serviceScoped) ErrWithRetryAfter(err error, after time.Duration) error {
+	return s.ErrorWithRetryAfter(err, after)
+}
+
+func (s *serviceScoped) ErrorWithRetryAfter(err error, after time.Duration) error {
+	if after <= 0 {
+		panic("errfmt: retry delay must be positive value")
+	}
+
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewRetryAfterValue(after),
+		NewValue(KindScope, s.scope)))
+}
+
+func (s *serviceScoped) ErrWithSeverity(err error, severity Severity) error {
+	return s.ErrorWithSeverity(err, severity)
+}
+
+func (s *serviceScoped) ErrorWithSeverity(err error, severity Severity) error {
+	if severity == SeverityUnknown {
+		panic("errfmt: severity must be known value")
+	}
+
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewSeverityValue(severity),
+		NewValue(KindScope, s.scope)))
 }
 
 func (s *serviceScoped) ErrNoWrap(err error) error {
@@ -69,27 +141,86 @@ func (s *serviceScoped) ErrorNoWrap(err error) error {
 }
 
 func (s *serviceScoped) ErrorOnly(err error, details ...string) error {
-	return ScopedErrorOnly(err, s.scope, details...)
+	return s.errorWithValues(err, captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.severityValues(), details...)
 }
 
 func (s *serviceScoped) Error(err error, details ...string) error {
-	return ScopedError(err, s.scope, details...)
+	return s.errorWithValues(err, captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.severityValues(), details...)
 }
 
 func (s *serviceScoped) Errorf(err error, format string, args ...interface{}) error {
-	return ScopedErrorf(err, s.scope, format, args...)
+	return s.errorWithValues(err, captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.severityValues(), fmt.Sprintf(format, args...))
 }
 
 func (s *serviceScoped) NewError(details ...string) error {
-	return NewScopedError(s.scope, details...)
+	return s.newErrorWithValues(captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.severityValues(), details...)
 }
 
 func (s *serviceScoped) NewErrorf(format string, args ...interface{}) error {
-	return NewScopedErrorf(format, s.scope, args...)
+	return s.newErrorWithValues(captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.severityValues(), fmt.Sprintf(format, args...))
+}
+
+func (s *serviceScoped) ErrorCtx(ctx context.Context, err error, details ...string) error {
+	return s.errorWithValues(err, captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.valuesWith(ContextValues(ctx)), details...)
 }
 
-func NewScopedErrorFormatter(scope string) *serviceScoped {
+func (s *serviceScoped) ErrorfCtx(ctx context.Context, err error, format string, args ...interface{}) error {
+	return s.errorWithValues(err, captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.valuesWith(ContextValues(ctx)), fmt.Sprintf(format, args...))
+}
+
+func (s *serviceScoped) NewErrorCtx(ctx context.Context, details ...string) error {
+	return s.newErrorWithValues(captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.valuesWith(ContextValues(ctx)), details...)
+}
+
+func (s *serviceScoped) NewErrorfCtx(ctx context.Context, format string, args ...interface{}) error {
+	return s.newErrorWithValues(captureStack(s.options.isStackCaptureEnabled, 1),
+		s.options.valuesWith(ContextValues(ctx)), fmt.Sprintf(format, args...))
+}
+
+// errorWithValues returns scoped error or valued error with scope, if values list is not empty or stack is captured...
+func (s *serviceScoped) errorWithValues(err error, stack Stack, values []Value, details ...string) error {
+	if err == nil {
+		return nil
+	}
+
+	if len(values) == 0 && len(stack) == 0 {
+		return scopedErrorOnly(err, s.options.layout, s.scope, details...)
+	}
+
+	values = append([]Value{NewValue(KindScope, s.scope)}, values...)
+
+	return observed(multiValuedErrorOnly(err, stack, s.options.layout, append(values, NewValue(KindDetails, details))...))
+}
+
+func (s *serviceScoped) newErrorWithValues(stack Stack, values []Value, details ...string) error {
+	if len(values) == 0 && len(stack) == 0 {
+		return newScopedError(s.options.layout, s.scope, details...)
+	}
+
+	return observed(valuedNewError(stack, s.options.layout, append([]Value{NewValue(KindScope, s.scope)}, values...),
+		details...))
+}
+
+func (s *serviceScoped) WithScope(scope string) Formatter {
+	if scope == "" {
+		panic("errfmt: scope must be not empty")
+	}
+
 	return &serviceScoped{
-		scope: scope,
+		scope:   JoinScope(s.scope, scope),
+		options: s.options,
 	}
 }
+
+// NewScopedErrorFormatter returns formatter service in scoped mode, same with New(WithScope(scope))...
+func NewScopedErrorFormatter(scope string, opts ...Option) Formatter {
+	return New(append(slices.Clip(opts), WithScope(scope))...)
+}
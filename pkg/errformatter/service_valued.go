@@ -32,9 +32,17 @@
 
 package errformatter
 
-var _ selfService = (*serviceValued)(nil)
+import (
+	"context"
+	"slices"
+	"time"
+)
 
-type serviceValued struct{}
+var _ Formatter = (*serviceValued)(nil)
+
+type serviceValued struct {
+	options options
+}
 
 func (s *serviceValued) ErrGetCode(err error) int {
 	return s.ErrorGetCode(err)
@@ -48,6 +56,62 @@ func (s *serviceValued) ErrWithCode(err error, code int) error {
 	return s.ErrorWithCode(err, code)
 }
 
+func (s *serviceValued) ErrGetPublicCode(err error) int {
+	return s.ErrorGetPublicCode(err)
+}
+
+func (s *serviceValued) ErrorGetPublicCode(err error) int {
+	return ValuedErrorGetPublicCode(err)
+}
+
+func (s *serviceValued) ErrWithPublicCode(err error, publicCode int) error {
+	return s.ErrorWithPublicCode(err, publicCode)
+}
+
+func (s *serviceValued) ErrorWithPublicCode(err error, publicCode int) error {
+	if publicCode <= 0 {
+		panic("errfmt: public code must be positive value")
+	}
+
+	return observed(valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewValue(KindPublicCode, publicCode)))
+}
+
+func (s *serviceValued) ErrWithRetry(err error, isRetryable bool) error {
+	return s.ErrorWithRetry(err, isRetryable)
+}
+
+func (s *serviceValued) ErrorWithRetry(err error, isRetryable bool) error {
+	return observed(valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewRetryValue(isRetryable)))
+}
+
+func (s *serviceValued) ErrWithRetryAfter(err error, after time.Duration) error {
+	return s.ErrorWithRetryAfter(err, after)
+}
+
+func (s *serviceValued) ErrorWithRetryAfter(err error, after time.Duration) error {
+	if after <= 0 {
+		panic("errfmt: retry delay must be positive value")
+	}
+
+	return observed(valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewRetryAfterValue(after)))
+}
+
+func (s *serviceValued) ErrWithSeverity(err error, severity Severity) error {
+	return s.ErrorWithSeverity(err, severity)
+}
+
+func (s *serviceValued) ErrorWithSeverity(err error, severity Severity) error {
+	if severity == SeverityUnknown {
+		panic("errfmt: severity must be known value")
+	}
+
+	return observed(valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewSeverityValue(severity)))
+}
+
 func (s *serviceValued) ErrNoWrap(err error) error {
 	return s.ErrorNoWrap(err)
 }
@@ -61,36 +125,107 @@ func (s *serviceValued) ErrorWithCode(err error, code int) error {
 		panic("errfmt: code must be positive value")
 	}
 
-	return ValuedErrorOnly(err, NewValue(KindCode, code))
+	catalogValues := s.options.catalogValues(code)
+
+	vErr := valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewValue(KindCode, code))
+	if vErr != nil {
+		// public code and severity of code are not part of error text, so they are set to same error node
+		_ = vErr.setValues(catalogValues...)
+	}
+
+	return observed(vErr)
 }
 
 func (s *serviceValued) ErrorOnly(err error, details ...string) error {
-	return ValuedErrorOnly(err, NewValue(KindDetails, details))
+	return observed(valuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewValue(KindDetails, details)))
 }
 
 func (s *serviceValued) Errorf(err error, format string, args ...interface{}) error {
-	return ValuedErrorf(err, nil, format, args...)
+	return observed(valuedErrorf(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		nil, format, args...))
 }
 
 func (s *serviceValued) Error(err error, details ...string) error {
-	return ValuedError(err, nil, details...)
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		NewValue(KindDetails, details)))
 }
 
 func (s *serviceValued) NewError(details ...string) error {
-	return ValuedNewError(nil, details...)
+	return observed(valuedNewError(captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		nil, details...))
 }
 
 func (s *serviceValued) NewErrorf(format string, args ...interface{}) error {
-	return ValuedNewErrorf(nil, format, args...)
+	return observed(valuedNewErrorf(captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		nil, format, args...))
+}
+
+func (s *serviceValued) ErrorCtx(ctx context.Context, err error, details ...string) error {
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		append(ContextValues(ctx), NewValue(KindDetails, details))...))
+}
+
+func (s *serviceValued) ErrorfCtx(ctx context.Context, err error, format string, args ...interface{}) error {
+	return observed(valuedErrorf(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		ContextValues(ctx), format, args...))
 }
 
-func NewValuesErrorFormatter(values ...Value) selfService {
+func (s *serviceValued) NewErrorCtx(ctx context.Context, details ...string) error {
+	return observed(valuedNewError(captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		ContextValues(ctx), details...))
+}
+
+func (s *serviceValued) NewErrorfCtx(ctx context.Context, format string, args ...interface{}) error {
+	return observed(valuedNewErrorf(captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		ContextValues(ctx), format, args...))
+}
+
+func (s *serviceValued) WithScope(scope string) Formatter {
+	if scope == "" {
+		panic("errfmt: scope must be not empty")
+	}
+
+	return &serviceValuedWithDefaults{
+		serviceValued: s,
+		defaultValues: []Value{NewValue(KindScope, JoinScope("", scope))},
+	}
+}
+
+// NewValuesErrorFormatter returns formatter service in valued mode, same with New(WithValues(values...))...
+func NewValuesErrorFormatter(values ...Value) Formatter {
+	return New(WithValues(values...))
+}
+
+// NewValuesErrorFormatterWithOptions same with NewValuesErrorFormatter, but with optional settings of service...
+func NewValuesErrorFormatterWithOptions(values []Value, opts ...Option) Formatter {
+	return New(append(slices.Clip(opts), WithValues(values...))...)
+}
+
+func newValuesErrorFormatter(values []Value, options options) Formatter {
+	svc := &serviceValued{
+		options: options,
+	}
+
+	// public code and severity of default code are taken from catalog, given values list overwrites them
+	for i := range values {
+		if values[i].KindOf(KindCode) {
+			values = append(svc.options.catalogValues(values[i].GetCode()), values...)
+
+			break
+		}
+	}
+
+	// default severity of service can be overwritten by severity value from given values list
+	values = svc.options.valuesWith(values)
+
 	if len(values) > 0 {
 		return &serviceValuedWithDefaults{
-			serviceValued: &serviceValued{},
+			serviceValued: svc,
 			defaultValues: values,
 		}
 	}
 
-	return &serviceValued{}
+	return svc
 }
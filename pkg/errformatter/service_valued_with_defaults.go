@@ -32,7 +32,12 @@
 
 package errformatter
 
-var _ selfService = (*serviceValuedWithDefaults)(nil)
+import (
+	"context"
+	"time"
+)
+
+var _ Formatter = (*serviceValuedWithDefaults)(nil)
 
 type serviceValuedWithDefaults struct {
 	*serviceValued
@@ -48,16 +53,95 @@ func (s *serviceValuedWithDefaults) ErrorWithCode(err error, code int) error {
 		panic("errfmt: code must be positive value")
 	}
 
+	catalogValues := s.options.catalogValues(code)
 	count := len(s.defaultValues)
 
-	valuesList := make([]Value, count+1)
+	valuesList := make([]Value, count+1, count+1+len(catalogValues))
 	copy(valuesList, s.defaultValues)
 	valuesList[count] = NewValue(KindCode, code)
+	valuesList = append(valuesList, catalogValues...)
+
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		valuesList...))
+}
+
+func (s *serviceValuedWithDefaults) ErrWithPublicCode(err error, publicCode int) error {
+	return s.ErrorWithPublicCode(err, publicCode)
+}
+
+func (s *serviceValuedWithDefaults) ErrorWithPublicCode(err error, publicCode int) error {
+	if publicCode <= 0 {
+		panic("errfmt: public code must be positive value")
+	}
+
+	count := len(s.defaultValues)
+
+	valuesList := make([]Value, count+1)
+	copy(valuesList, s.defaultValues)
+	valuesList[count] = NewValue(KindPublicCode, publicCode)
+
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		valuesList...))
+}
+
+func (s *serviceValuedWithDefaults) ErrWithRetry(err error, isRetryable bool) error {
+	return s.ErrorWithRetry(err, isRetryable)
+}
+
+func (s *serviceValuedWithDefaults) ErrorWithRetry(err error, isRetryable bool) error {
+	count := len(s.defaultValues)
+
+	valuesList := make([]Value, count+1)
+	copy(valuesList, s.defaultValues)
+	valuesList[count] = NewRetryValue(isRetryable)
+
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		valuesList...))
+}
+
+func (s *serviceValuedWithDefaults) ErrWithRetryAfter(err error, after time.Duration) error {
+	return s.ErrorWithRetryAfter(err, after)
+}
+
+func (s *serviceValuedWithDefaults) ErrorWithRetryAfter(err error, after time.Duration) error {
+	if after <= 0 {
+		panic("errfmt: retry delay must be positive value")
+	}
+
+	count := len(s.defaultValues)
+
+	valuesList := make([]Value, count+1)
+	copy(valuesList, s.defaultValues)
+	valuesList[count] = NewRetryAfterValue(after)
+
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		valuesList...))
+}
+
+func (s *serviceValuedWithDefaults) ErrWithSeverity(err error, severity Severity) error {
+	return s.ErrorWithSeverity(err, severity)
+}
+
+func (s *serviceValuedWithDefaults) ErrorWithSeverity(err error, severity Severity) error {
+	if severity == SeverityUnknown {
+		panic("errfmt: severity must be known value")
+	}
 
-	return MultiValuedErrorOnly(err, valuesList...)
+	count := len(s.defaultValues)
+
+	valuesList := make([]Value, count+1)
+	copy(valuesList, s.defaultValues)
+	valuesList[count] = NewSeverityValue(severity)
+
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		valuesList...))
 }
 
 func (s *serviceValuedWithDefaults) ErrorOnly(err error, details ...string) error {
+	return s.errorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), details...)
+}
+
+func (s *serviceValuedWithDefaults) errorOnly(err error, stack Stack, details ...string) error {
 	count := len(s.defaultValues)
 
 	if len(details) > 0 {
@@ -66,17 +150,17 @@ func (s *serviceValuedWithDefaults) ErrorOnly(err error, details ...string) erro
 
 		valuesList[count] = NewValue(KindDetails, details)
 
-		return MultiValuedErrorOnly(err, valuesList...)
+		return observed(multiValuedErrorOnly(err, stack, s.options.layout, valuesList...))
 	}
 
 	valuesList := make([]Value, count)
 	copy(valuesList[:count], s.defaultValues)
 
-	return MultiValuedErrorOnly(err, valuesList...)
+	return observed(multiValuedErrorOnly(err, stack, s.options.layout, valuesList...))
 }
 
 func (s *serviceValuedWithDefaults) Error(err error, details ...string) error {
-	return s.ErrorOnly(err, details...)
+	return s.errorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), details...)
 }
 
 func (s *serviceValuedWithDefaults) Errorf(err error,
@@ -88,7 +172,8 @@ func (s *serviceValuedWithDefaults) Errorf(err error,
 	valuesList := make([]Value, count)
 	copy(valuesList, s.defaultValues)
 
-	return ValuedErrorf(err, valuesList, format, args...)
+	return observed(valuedErrorf(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		valuesList, format, args...))
 }
 
 func (s *serviceValuedWithDefaults) NewError(details ...string) error {
@@ -97,7 +182,8 @@ func (s *serviceValuedWithDefaults) NewError(details ...string) error {
 	valuesList := make([]Value, count)
 	copy(valuesList, s.defaultValues)
 
-	return ValuedNewError(valuesList, details...)
+	return observed(valuedNewError(captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		valuesList, details...))
 }
 
 func (s *serviceValuedWithDefaults) NewErrorf(format string, args ...interface{}) error {
@@ -106,5 +192,72 @@ func (s *serviceValuedWithDefaults) NewErrorf(format string, args ...interface{}
 	valuesList := make([]Value, count)
 	copy(valuesList, s.defaultValues)
 
-	return ValuedNewErrorf(valuesList, format, args...)
+	return observed(valuedNewErrorf(captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		valuesList, format, args...))
+}
+
+func (s *serviceValuedWithDefaults) ErrorCtx(ctx context.Context, err error, details ...string) error {
+	valuesList := s.defaultValuesWith(ContextValues(ctx))
+	if len(details) > 0 {
+		valuesList = append(valuesList, NewValue(KindDetails, details))
+	}
+
+	return observed(multiValuedErrorOnly(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		valuesList...))
+}
+
+func (s *serviceValuedWithDefaults) ErrorfCtx(ctx context.Context,
+	err error,
+	format string,
+	args ...interface{},
+) error {
+	return observed(valuedErrorf(err, captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		s.defaultValuesWith(ContextValues(ctx)), format, args...))
+}
+
+func (s *serviceValuedWithDefaults) NewErrorCtx(ctx context.Context, details ...string) error {
+	return observed(valuedNewError(captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		s.defaultValuesWith(ContextValues(ctx)), details...))
+}
+
+func (s *serviceValuedWithDefaults) NewErrorfCtx(ctx context.Context, format string, args ...interface{}) error {
+	return observed(valuedNewErrorf(captureStack(s.options.isStackCaptureEnabled, 1), s.options.layout,
+		s.defaultValuesWith(ContextValues(ctx)), format, args...))
+}
+
+// defaultValuesWith returns copy of default values list with given values at the end...
+func (s *serviceValuedWithDefaults) defaultValuesWith(values []Value) []Value {
+	count := len(s.defaultValues)
+
+	valuesList := make([]Value, count, count+len(values)+1)
+	copy(valuesList, s.defaultValues)
+
+	return append(valuesList, values...)
+}
+
+func (s *serviceValuedWithDefaults) WithScope(scope string) Formatter {
+	if scope == "" {
+		panic("errfmt: scope must be not empty")
+	}
+
+	var parentScope string
+
+	valuesList := make([]Value, 0, len(s.defaultValues)+1)
+
+	for i := range s.defaultValues {
+		if s.defaultValues[i].KindOf(KindScope) {
+			parentScope = s.defaultValues[i].getScope()
+
+			continue
+		}
+
+		valuesList = append(valuesList, s.defaultValues[i])
+	}
+
+	valuesList = append(valuesList, NewValue(KindScope, JoinScope(parentScope, scope)))
+
+	return &serviceValuedWithDefaults{
+		serviceValued: s.serviceValued,
+		defaultValues: valuesList,
+	}
 }
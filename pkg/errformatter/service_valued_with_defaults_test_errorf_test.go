@@ -246,7 +246,7 @@ func TestServiceValuedWithDefaults_Errorf_ReWrap(t *testing.T) {
 					reWrappedErr.Error(), reWrappedExpectedResult)
 			}
 
-			unwrappedReWrappedErr := errors.Unwrap(err)
+			unwrappedReWrappedErr := errors.Unwrap(reWrappedErr)
 			if unwrappedReWrappedErr.Error() != expectedResult {
 				t.Errorf("error text not equal with expected. current: %s, expected: %s",
 					unwrappedReWrappedErr.Error(), expectedResult)
@@ -318,7 +318,7 @@ func TestServiceValuedWithDefaults_Errorf_ReWrap(t *testing.T) {
 					reWrappedErr.Error(), reWrappedExpectedResult)
 			}
 
-			unwrappedReWrappedErr := errors.Unwrap(err)
+			unwrappedReWrappedErr := errors.Unwrap(reWrappedErr)
 			if unwrappedReWrappedErr.Error() != expectedResult {
 				t.Errorf("error text not equal with expected. current: %s, expected: %s",
 					unwrappedReWrappedErr.Error(), expectedResult)
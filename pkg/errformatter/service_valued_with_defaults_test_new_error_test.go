@@ -211,7 +211,7 @@ func TestServiceValuedWithDefaults_NewError_ReWrap(t *testing.T) {
 				reWrappedErr.Error(), expectedReWrappedResult)
 		}
 
-		if code := svc.ErrorGetCode(err); code != reWrappedExpectedCode {
+		if code := svc.ErrorGetCode(reWrappedErr); code != reWrappedExpectedCode {
 			t.Errorf("error code not equal with expected. current: %d, expected: %d",
 				code, reWrappedExpectedCode)
 		}
@@ -182,9 +182,9 @@ func TestServiceValuedWithDefaults_NewErrorf_ReWrap(t *testing.T) {
 			}
 
 			reWrapErr := svc.ErrorOnly(err, "re_wrap_detail_1")
-			if err.Error() != reWrapExpectedResult {
+			if reWrapErr.Error() != reWrapExpectedResult {
 				t.Errorf("error text not equal with expected. current: %s, expected: %s",
-					err.Error(), reWrapExpectedResult)
+					reWrapErr.Error(), reWrapExpectedResult)
 			}
 
 			unWrappedReWrapErr := errors.Unwrap(reWrapErr)
@@ -0,0 +1,157 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import "log/slog"
+
+// Severity - level of error importance...
+type Severity uint8
+
+const (
+	SeverityUnknown Severity = iota
+	SeverityDebug
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+
+	SeverityUnknownName  = "unknown"
+	SeverityDebugName    = "debug"
+	SeverityInfoName     = "info"
+	SeverityWarningName  = "warning"
+	SeverityErrorName    = "error"
+	SeverityCriticalName = "critical"
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityUnknown:
+		return SeverityUnknownName
+	case SeverityDebug:
+		return SeverityDebugName
+	case SeverityInfo:
+		return SeverityInfoName
+	case SeverityWarning:
+		return SeverityWarningName
+	case SeverityError:
+		return SeverityErrorName
+	case SeverityCritical:
+		return SeverityCriticalName
+	default:
+		return SeverityUnknownName
+	}
+}
+
+// Level returns slog level of severity. Critical severity is mapped to LevelCritical,
+// unknown severity - to slog.LevelError...
+func (s Severity) Level() slog.Level {
+	switch s {
+	case SeverityDebug:
+		return slog.LevelDebug
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarning:
+		return slog.LevelWarn
+	case SeverityCritical:
+		return LevelCritical
+	case SeverityUnknown, SeverityError:
+		return slog.LevelError
+	default:
+		return slog.LevelError
+	}
+}
+
+// ParseSeverity returns severity by name. Returns false if name is unknown...
+func ParseSeverity(name string) (Severity, bool) {
+	for severity := SeverityDebug; severity <= SeverityCritical; severity++ {
+		if severity.String() == name {
+			return severity, true
+		}
+	}
+
+	return SeverityUnknown, false
+}
+
+// NewSeverityValue returns Value of KindSeverity...
+func NewSeverityValue(severity Severity) Value {
+	return NewValue(KindSeverity, severity)
+}
+
+// ErrorSeverity walks by cause chain of error, including errors re-wrapped by copy-on-write
+// and members of multi-error, and returns max severity of chain.
+// Returns SeverityUnknown if no errors of chain have severity...
+func ErrorSeverity(err error) Severity {
+	result := SeverityUnknown
+	queue := []error{err}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == nil {
+			continue
+		}
+
+		//nolint:errorlint // it's ok - each error of chain is checked separately
+		switch typedErr := current.(type) {
+		case *valuedError:
+			result = max(result, typedErr.severity())
+
+			// previous error keeps own severity, which is overwritten by re-wrap
+			if typedErr.previous != nil {
+				queue = append(queue, typedErr.previous)
+			}
+		case *multiError:
+			result = max(result, typedErr.valued.severity())
+		}
+
+		//nolint:errorlint // it's ok - here we need to check direct implementation of Unwrap
+		switch unwrapper := current.(type) {
+		case interface{ Unwrap() []error }:
+			queue = append(queue, unwrapper.Unwrap()...)
+		case interface{ Unwrap() error }:
+			queue = append(queue, unwrapper.Unwrap())
+		}
+	}
+
+	return result
+}
+
+// severity returns severity value of error or SeverityUnknown if value is not set...
+func (e *valuedError) severity() Severity {
+	if !e.settled.Has(ValueSeverityIsSet) {
+		return SeverityUnknown
+	}
+
+	return e.values[KindSeverity].getSeverity()
+}
@@ -0,0 +1,194 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestErrorSeverity(t *testing.T) {
+	t.Run("error severity - max severity of chain", func(t *testing.T) {
+		svc := NewErrorFormatter()
+
+		warningErr := svc.ErrorWithSeverity(errors.New("test error"), SeverityWarning)
+
+		// severity of re-wrapped error is overwritten, but max severity of chain is kept
+		infoErr := svc.ErrorWithSeverity(warningErr, SeverityInfo)
+		if severity := ErrorSeverity(infoErr); severity != SeverityWarning {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s", severity, SeverityWarning)
+		}
+
+		criticalErr := svc.ErrorWithSeverity(svc.Error(infoErr, "outer"), SeverityCritical)
+		if severity := ErrorSeverity(criticalErr); severity != SeverityCritical {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s", severity, SeverityCritical)
+		}
+
+		if severity := ErrorSeverity(errors.New("test error")); severity != SeverityUnknown {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s", severity, SeverityUnknown)
+		}
+
+		multiErr := NewMultiError(nil, warningErr, errors.New("plain error"), criticalErr)
+		if severity := ErrorSeverity(multiErr); severity != SeverityCritical {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s", severity, SeverityCritical)
+		}
+	})
+
+	t.Run("service with default severity - errors text is not changed", func(t *testing.T) {
+		services := map[string][2]Formatter{
+			"plain": {
+				NewErrorFormatter(),
+				NewErrorFormatter(WithSeverity(SeverityError)),
+			},
+			"scoped": {
+				NewScopedErrorFormatter("scope"),
+				NewScopedErrorFormatter("scope", WithSeverity(SeverityError)),
+			},
+			"valued": {
+				NewValuesErrorFormatter(NewValue(KindScope, "scope")),
+				NewValuesErrorFormatterWithOptions([]Value{NewValue(KindScope, "scope")},
+					WithSeverity(SeverityError)),
+			},
+		}
+
+		for name, pair := range services {
+			plainSvc, severitySvc := pair[0], pair[1]
+
+			expectedErr := plainSvc.Errorf(errors.New("test error"), "detail_%d", 1)
+			err := severitySvc.Errorf(errors.New("test error"), "detail_%d", 1)
+
+			if err.Error() != expectedErr.Error() {
+				t.Errorf("%s: error text not equal with expected. current: %s, expected: %s",
+					name, err.Error(), expectedErr.Error())
+			}
+
+			if severity := ErrorSeverity(err); severity != SeverityError {
+				t.Errorf("%s: severity not equal with expected. current: %s, expected: %s",
+					name, severity, SeverityError)
+			}
+
+			if severity := ErrorSeverity(severitySvc.NewError("test error")); severity != SeverityError {
+				t.Errorf("%s: severity not equal with expected. current: %s, expected: %s",
+					name, severity, SeverityError)
+			}
+		}
+	})
+
+	t.Run("service with default severity - nil error is not wrapped", func(t *testing.T) {
+		services := map[string]Formatter{
+			"plain":  NewErrorFormatter(WithSeverity(SeverityError)),
+			"scoped": NewScopedErrorFormatter("scope", WithSeverity(SeverityError)),
+			"valued": NewValuesErrorFormatterWithOptions([]Value{NewValue(KindScope, "scope")},
+				WithSeverity(SeverityError)),
+		}
+
+		for name, svc := range services {
+			if err := svc.Error(nil, "detail_1"); err != nil {
+				t.Errorf("%s: error must be nil. current: %#v", name, err)
+			}
+
+			if err := svc.ErrorOnly(nil, "detail_1"); err != nil {
+				t.Errorf("%s: error must be nil. current: %#v", name, err)
+			}
+
+			if err := svc.Errorf(nil, "detail_%d", 1); err != nil {
+				t.Errorf("%s: error must be nil. current: %#v", name, err)
+			}
+		}
+	})
+
+	t.Run("service with catalog - severity of registered code", func(t *testing.T) {
+		svc := NewErrorFormatter(WithCatalog(newTestCatalog()), WithSeverity(SeverityInfo))
+
+		err := svc.ErrorWithCode(svc.Error(errors.New("test error")), 500)
+		if severity := ErrorSeverity(err); severity != SeverityCritical {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s", severity, SeverityCritical)
+		}
+	})
+
+	t.Run("severity value - json round trip and %+v report", func(t *testing.T) {
+		err := NewErrorFormatter().ErrorWithSeverity(errors.New("test error"), SeverityWarning)
+
+		rawErr, marshalErr := json.Marshal(err)
+		if marshalErr != nil {
+			t.Fatalf("unable to marshal error: %v", marshalErr)
+		}
+
+		if !strings.Contains(string(rawErr), `"severity":"warning"`) {
+			t.Errorf("json not contains severity. current: %s", rawErr)
+		}
+
+		if severity := ErrorSeverity(DecodeError(rawErr)); severity != SeverityWarning {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s", severity, SeverityWarning)
+		}
+
+		if report := fmt.Sprintf("%+v", err); !strings.Contains(report, "severity: warning") {
+			t.Errorf("report not contains severity. current: %s", report)
+		}
+	})
+}
+
+func TestSlogHandlerSeverity(t *testing.T) {
+	t.Run("slog handler - record level is raised by error severity", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		logger := slog.New(NewSlogHandler(slog.NewJSONHandler(&buffer, nil)))
+		svc := NewErrorFormatter()
+
+		logger.Warn("test message", slog.Any("err",
+			svc.ErrorWithSeverity(errors.New("test error"), SeverityCritical)))
+
+		if !strings.Contains(buffer.String(), `"level":"ERROR+4"`) {
+			t.Errorf("log record level not equal with expected. current: %s", buffer.String())
+		}
+	})
+
+	t.Run("slog handler - record level is not lowered by error severity", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		logger := slog.New(NewSlogHandler(slog.NewJSONHandler(&buffer,
+			&slog.HandlerOptions{Level: slog.LevelWarn})))
+
+		logger.Error("test message", slog.Any("err",
+			NewErrorFormatter().ErrorWithSeverity(errors.New("test error"), SeverityDebug)))
+
+		if !strings.Contains(buffer.String(), `"level":"ERROR"`) {
+			t.Errorf("log record level not equal with expected. current: %s", buffer.String())
+		}
+	})
+}
@@ -0,0 +1,258 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+const (
+	LogAttrMessage    = "message"
+	LogAttrScope      = "scope"
+	LogAttrCode       = "code"
+	LogAttrPublicCode = "public_code"
+	LogAttrDetails    = "details"
+	LogAttrRetry      = "retry"
+	LogAttrSeverity   = "severity"
+
+	// LevelCritical - slog level of errors with critical severity...
+	LevelCritical = slog.LevelError + 4
+)
+
+var _ slog.LogValuer = (*valuedError)(nil)
+
+// LogValue implements slog.LogValuer interface, returns group with message and all values of error...
+func (e valuedError) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(e.values)+len(e.custom))
+	attrs = append(attrs, slog.String(LogAttrMessage, Redact(e.Error())))
+
+	return slog.GroupValue(e.logAttrs(attrs)...)
+}
+
+func (e *valuedError) logAttrs(attrs []slog.Attr) []slog.Attr {
+	if e.settled.Has(ValueScopeIsSet) {
+		attrs = append(attrs, slog.String(LogAttrScope, e.values[KindScope].getScope()))
+	}
+
+	if e.settled.Has(ValueCodeIsSet) {
+		attrs = append(attrs, slog.Int(LogAttrCode, e.values[KindCode].getCode()))
+	}
+
+	if e.settled.Has(ValuePublicCodeIsSet) {
+		attrs = append(attrs, slog.Int(LogAttrPublicCode, e.values[KindPublicCode].getPublicCode()))
+	}
+
+	if e.settled.Has(ValueRetryIsSet) {
+		attrs = append(attrs, slog.String(LogAttrRetry, e.values[KindRetry].getRetry().String()))
+	}
+
+	if e.settled.Has(ValueSeverityIsSet) {
+		attrs = append(attrs, slog.String(LogAttrSeverity, e.values[KindSeverity].getSeverity().String()))
+	}
+
+	if e.settled.Has(ValueDetailsIsSet) {
+		attrs = append(attrs, slog.Any(LogAttrDetails, redactDetails(e.values[KindDetails].getDetails())))
+	}
+
+	for i := range e.custom {
+		attrs = append(attrs, slog.Any(e.custom[i].num.String(), e.custom[i].any))
+	}
+
+	return attrs
+}
+
+// mergeMissingValues sets values of given error, which are not set in current error. Details are merged...
+func (e *valuedError) mergeMissingValues(other *valuedError) {
+	for kind := KindDetails; kind <= MaxKindValue; kind++ {
+		if !other.settled.Has(kind.Bits()) {
+			continue
+		}
+
+		switch {
+		case kind == KindDetails && e.settled.Has(ValueDetailsIsSet):
+			_ = e.mergeDetails(other.values[KindDetails].getDetails()...)
+		case !e.settled.Has(kind.Bits()):
+			_ = e.setValue(other.values[kind])
+		}
+	}
+
+	for i := range other.custom {
+		if !e.settled.Has(other.custom[i].num.Bits()) {
+			_ = e.setCustomValue(other.custom[i])
+		}
+	}
+}
+
+// collectValues walks by cause chain of error and collects values of all nested valued errors.
+// Values of outer errors have priority...
+func collectValues(err error) (*valuedError, bool) {
+	var (
+		collected valuedError
+		isFound   bool
+	)
+
+	queue := []error{err}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == nil {
+			continue
+		}
+
+		//nolint:errorlint // it's ok - each error of chain is checked separately
+		if vErr, isValued := current.(*valuedError); isValued {
+			isFound = true
+
+			collected.mergeMissingValues(vErr)
+		}
+
+		//nolint:errorlint // it's ok - here we need to check direct implementation of Unwrap
+		switch unwrapper := current.(type) {
+		case interface{ Unwrap() []error }:
+			queue = append(queue, unwrapper.Unwrap()...)
+		case interface{ Unwrap() error }:
+			queue = append(queue, unwrapper.Unwrap())
+		}
+	}
+
+	return &collected, isFound
+}
+
+// slogHandler - slog.Handler middleware, which expands error attributes to group of error values...
+type slogHandler struct {
+	next slog.Handler
+}
+
+// NewSlogHandler wraps given slog.Handler. All error attributes of log records are expanded to group
+// with message and values, collected from all nested valued errors of cause chain.
+// Level of log record with error attribute is raised to level of max error severity, see ErrorSeverity.
+// Level of log record is never lowered by error severity...
+func NewSlogHandler(next slog.Handler) *slogHandler {
+	return &slogHandler{
+		next: next,
+	}
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	severity := SeverityUnknown
+
+	record.Attrs(func(attr slog.Attr) bool {
+		// valued errors are slog.LogValuer, other errors are stored as any
+		if attr.Value.Kind() == slog.KindAny || attr.Value.Kind() == slog.KindLogValuer {
+			if err, isError := attr.Value.Any().(error); isError {
+				severity = max(severity, ErrorSeverity(err))
+			}
+		}
+
+		return true
+	})
+
+	// record level was checked by caller, so raised level is enabled too
+	level := record.Level
+	if severity != SeverityUnknown {
+		level = max(record.Level, severity.Level())
+	}
+
+	expanded := slog.NewRecord(record.Time, level, record.Message, record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		expanded.AddAttrs(expandErrorAttr(attr))
+
+		return true
+	})
+
+	err := h.next.Handle(ctx, expanded)
+	if err != nil {
+		return fmt.Errorf("unable to handle log record: %w", err)
+	}
+
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i := range attrs {
+		expanded[i] = expandErrorAttr(attrs[i])
+	}
+
+	return &slogHandler{
+		next: h.next.WithAttrs(expanded),
+	}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	return &slogHandler{
+		next: h.next.WithGroup(name),
+	}
+}
+
+func expandErrorAttr(attr slog.Attr) slog.Attr {
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		groupAttrs := attr.Value.Group()
+		expanded := make([]slog.Attr, len(groupAttrs))
+
+		for i := range groupAttrs {
+			expanded[i] = expandErrorAttr(groupAttrs[i])
+		}
+
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(expanded...)}
+
+	case slog.KindAny:
+		err, isError := attr.Value.Any().(error)
+		if !isError || err == nil {
+			return attr
+		}
+
+		collected, isFound := collectValues(err)
+		if !isFound {
+			return attr
+		}
+
+		attrs := make([]slog.Attr, 0, len(collected.values)+len(collected.custom))
+		attrs = append(attrs, slog.String(LogAttrMessage, Redact(err.Error())))
+
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(collected.logAttrs(attrs)...)}
+
+	default:
+		return attr
+	}
+}
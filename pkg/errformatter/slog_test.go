@@ -0,0 +1,107 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogIntegration(t *testing.T) {
+	t.Run("valued error - LogValue returns group of values", func(t *testing.T) {
+		const expectedResult = `"err":{"message":"valued_err_scope: test error -> detail_1",` +
+			`"scope":"valued_err_scope","code":404,"details":["detail_1"]}`
+
+		var buffer bytes.Buffer
+
+		logger := slog.New(slog.NewJSONHandler(&buffer, nil))
+
+		err := MultiValuedErrorOnly(errors.New("test error"),
+			NewValue(KindScope, "valued_err_scope"),
+			NewValue(KindCode, 404),
+			NewValue(KindDetails, []string{"detail_1"}),
+		)
+
+		logger.Error("test message", slog.Any("err", err))
+
+		if !strings.Contains(buffer.String(), expectedResult) {
+			t.Errorf("log record not contains expected error group. current: %s, expected: %s",
+				buffer.String(), expectedResult)
+		}
+	})
+
+	t.Run("slog handler - values of nested valued errors are collected", func(t *testing.T) {
+		const expectedResult = `"err":{"message":"outer: valued_err_scope: test error -> detail_1",` +
+			`"scope":"valued_err_scope","code":404,"details":["detail_1"],"test_kind_chain_name":"ethereum"}`
+
+		var buffer bytes.Buffer
+
+		logger := slog.New(NewSlogHandler(slog.NewJSONHandler(&buffer, nil)))
+
+		valuedErr := MultiValuedErrorOnly(errors.New("test error"),
+			NewValue(KindScope, "valued_err_scope"),
+			NewValue(KindCode, 404),
+			NewValue(KindDetails, []string{"detail_1"}),
+			NewValue(testKindChainName, "ethereum"),
+		)
+
+		logger.Error("test message", slog.Any("err", fmt.Errorf("outer: %w", valuedErr)))
+
+		if !strings.Contains(buffer.String(), expectedResult) {
+			t.Errorf("log record not contains expected error group. current: %s, expected: %s",
+				buffer.String(), expectedResult)
+		}
+	})
+
+	t.Run("slog handler - plain errors and attributes of logger", func(t *testing.T) {
+		const (
+			expectedPlain  = `"plain":"test error"`
+			expectedValued = `"err":{"message":"test error","code":404}`
+		)
+
+		var buffer bytes.Buffer
+
+		logger := slog.New(NewSlogHandler(slog.NewJSONHandler(&buffer, nil))).
+			With(slog.Any("err", ValuedErrorOnly(errors.New("test error"), NewValue(KindCode, 404))))
+
+		logger.Info("test message", slog.Any("plain", errors.New("test error")))
+
+		if !strings.Contains(buffer.String(), expectedPlain) || !strings.Contains(buffer.String(), expectedValued) {
+			t.Errorf("log record not contains expected attributes. current: %s", buffer.String())
+		}
+	})
+}
@@ -0,0 +1,131 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+//nolint:gochecknoglobals // it's ok - package-level switch of stack capture
+var isStackCaptureEnabled atomic.Bool
+
+// SetStackCapture enables or disables stack capture for all valued errors and formatter services...
+func SetStackCapture(isEnabled bool) {
+	isStackCaptureEnabled.Store(isEnabled)
+}
+
+// IsStackCaptureEnabled returns state of package-level stack capture switch...
+func IsStackCaptureEnabled() bool {
+	return isStackCaptureEnabled.Load()
+}
+
+// Stack is a list of program counters of function calls, from origin of error to top of goroutine stack...
+type Stack []uintptr
+
+// Frames returns resolved frames of stack...
+func (s Stack) Frames() []runtime.Frame {
+	if len(s) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(s)
+	result := make([]runtime.Frame, 0, len(s))
+
+	for {
+		frame, isMore := frames.Next()
+		result = append(result, frame)
+
+		if !isMore {
+			break
+		}
+	}
+
+	return result
+}
+
+// String returns stack in format of panic stack trace - function name and file:line pair on next line...
+func (s Stack) String() string {
+	frames := s.Frames()
+	if len(frames) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	for i := range frames {
+		builder.WriteString(frames[i].Function)
+		builder.WriteString("\n\t")
+		builder.WriteString(frames[i].File)
+		builder.WriteString(":")
+		builder.WriteString(strconv.Itoa(frames[i].Line))
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+// captureStack returns stack of function calls if capture is enabled.
+// Skip - count of frames to skip, 0 means caller of captureStack...
+func captureStack(isEnabled bool, skip int) Stack {
+	if !isEnabled && !IsStackCaptureEnabled() {
+		return nil
+	}
+
+	var pcs [MaxStackDepth]uintptr
+
+	// skip runtime.Callers and captureStack frames
+	count := runtime.Callers(skip+CallerStackSkip, pcs[:])
+	if count == 0 {
+		return nil
+	}
+
+	stack := make(Stack, count)
+	copy(stack, pcs[:count])
+
+	return stack
+}
+
+// ErrorStackTrace returns stack trace of valued error, captured at place of error origin...
+func ErrorStackTrace(err error) Stack {
+	var vErr *valuedError
+
+	if !errors.As(err, &vErr) {
+		return nil
+	}
+
+	return vErr.StackTrace()
+}
@@ -0,0 +1,156 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errformatter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTestOriginStackError() error {
+	return NewValuesErrorFormatterWithOptions([]Value{
+		NewValue(KindScope, "origin_scope"),
+	}, WithStackCapture()).NewError("origin error")
+}
+
+func TestStackCapture(t *testing.T) {
+	t.Run("valued new error - stack capture disabled by default", func(t *testing.T) {
+		err := ValuedNewError([]Value{NewValue(KindCode, 4)}, "error detail")
+		if stack := err.StackTrace(); stack != nil {
+			t.Errorf("stack must be empty if stack capture is disabled. current: %s", stack.String())
+		}
+	})
+
+	t.Run("valued new error - package-level stack capture switch", func(t *testing.T) {
+		SetStackCapture(true)
+		defer SetStackCapture(false)
+
+		err := ValuedNewError([]Value{NewValue(KindCode, 4)}, "error detail")
+
+		frames := err.StackTrace().Frames()
+		if len(frames) == 0 {
+			t.Fatalf("stack must be captured if stack capture is enabled")
+		}
+
+		if !strings.Contains(frames[0].Function, "TestStackCapture") {
+			t.Errorf("first frame must be caller of error constructor. current: %s", frames[0].Function)
+		}
+
+		err = MultiValuedErrorOnly(errors.New("test error"), NewValue(KindScope, "valued_err_scope"))
+		if len(err.StackTrace()) == 0 {
+			t.Errorf("stack must be captured if stack capture is enabled")
+		}
+	})
+
+	t.Run("service valued - per-service stack capture option", func(t *testing.T) {
+		svc := NewValuesErrorFormatterWithOptions([]Value{
+			NewValue(KindScope, "valued_err_scope"),
+		}, WithStackCapture())
+
+		err := svc.ErrorOnly(errors.New("test error"), "detail_1")
+
+		frames := ErrorStackTrace(err).Frames()
+		if len(frames) == 0 {
+			t.Fatalf("stack must be captured if stack capture option is enabled")
+		}
+
+		if !strings.Contains(frames[0].Function, "TestStackCapture") {
+			t.Errorf("first frame must be caller of service method. current: %s", frames[0].Function)
+		}
+
+		if stack := ErrorStackTrace(NewValuesErrorFormatter().NewError("test error")); stack != nil {
+			t.Errorf("stack must be empty if stack capture is disabled. current: %s", stack.String())
+		}
+	})
+
+	t.Run("service valued - re-wrap keeps stack of error origin", func(t *testing.T) {
+		svc := NewValuesErrorFormatterWithOptions([]Value{
+			NewValue(KindScope, "valued_err_scope"),
+		}, WithStackCapture())
+
+		err := svc.Error(newTestOriginStackError(), "detail_1")
+		err = svc.ErrorWithCode(err, 404)
+
+		frames := ErrorStackTrace(err).Frames()
+		if len(frames) == 0 {
+			t.Fatalf("stack must be captured if stack capture option is enabled")
+		}
+
+		if !strings.Contains(frames[0].Function, "newTestOriginStackError") {
+			t.Errorf("first frame must be place of error origin. current: %s", frames[0].Function)
+		}
+	})
+
+	t.Run("scoped service - stack of error with code", func(t *testing.T) {
+		svc := NewScopedErrorFormatter("scope", WithStackCapture())
+
+		err := svc.ErrorWithCode(errors.New("test error"), 404)
+		if !strings.Contains(ErrorStackTrace(err).String(), "stack_test.go") {
+			t.Errorf("stack must contain caller file. current: %s", ErrorStackTrace(err).String())
+		}
+	})
+	t.Run("plain and scoped services - stack of errors without values", func(t *testing.T) {
+		services := map[string]Formatter{
+			"plain":  NewErrorFormatter(WithStackCapture()),
+			"scoped": NewScopedErrorFormatter("scope", WithStackCapture()),
+		}
+
+		for name, svc := range services {
+			errs := []error{
+				svc.Error(errors.New("test error"), "detail_1"),
+				svc.ErrorOnly(errors.New("test error"), "detail_1"),
+				svc.Errorf(errors.New("test error"), "detail_%d", 1),
+				svc.NewError("detail_1"),
+				svc.NewErrorf("detail_%d", 1),
+			}
+
+			for i := range errs {
+				frames := ErrorStackTrace(errs[i]).Frames()
+				if len(frames) == 0 || !strings.Contains(frames[0].Function, "TestStackCapture") {
+					t.Errorf("%s: first frame must be caller of formatter. current: %+v", name, frames)
+				}
+			}
+
+			expectedErr := NewErrorFormatter().Error(errors.New("test error"), "detail_1")
+			if name == "scoped" {
+				expectedErr = NewScopedErrorFormatter("scope").Error(errors.New("test error"), "detail_1")
+			}
+
+			if errs[0].Error() != expectedErr.Error() {
+				t.Errorf("%s: error text not equal with expected. current: %s, expected: %s",
+					name, errs[0].Error(), expectedErr.Error())
+			}
+		}
+	})
+}
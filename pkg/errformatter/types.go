@@ -34,13 +34,16 @@ package errformatter
 
 import "fmt"
 
-type Bits uint8
+// Bits - presence flags of values. Each built-in or custom Kind owns one bit...
+type Bits uint64
 
 const (
 	ValueDetailsIsSet Bits = 1 << iota
 	ValueScopeIsSet
 	ValueCodeIsSet
 	ValuePublicCodeIsSet
+	ValueRetryIsSet
+	ValueSeverityIsSet
 )
 
 func (b *Bits) Set(flag Bits) {
@@ -71,6 +74,17 @@ type Value struct {
 }
 
 func NewValue(kind Kind, value any) Value {
+	if kind.isCustom() {
+		descriptor, isRegistered := customKinds.descriptor(kind)
+		if !isRegistered {
+			panic(fmt.Sprintf("errfmt: kind %d is not registered", kind))
+		}
+
+		if descriptor.validator != nil && !descriptor.validator(value) {
+			panic(fmt.Sprintf("errfmt: value of type %T is not allowed for kind %s", value, descriptor.name))
+		}
+	}
+
 	//nolint:exhaustruct //it's ok - field _ disallow struct comparison
 	return Value{
 		num: kind,
@@ -86,6 +100,11 @@ func (v *Value) KindOf(kind Kind) bool {
 	return v.num == kind
 }
 
+// Any returns raw value...
+func (v *Value) Any() any {
+	return v.any
+}
+
 func (v *Value) GetCode() int {
 	if g, w := v.Kind(), KindCode; g != w {
 		panic(fmt.Sprintf("Value kind is %s, not %s", g, w))
@@ -102,6 +121,38 @@ func (v *Value) getCode() int {
 	return ValueCodeMissing
 }
 
+func (v *Value) GetRetry() Retry {
+	if g, w := v.Kind(), KindRetry; g != w {
+		panic(fmt.Sprintf("Value kind is %s, not %s", g, w))
+	}
+
+	return v.getRetry()
+}
+
+func (v *Value) getRetry() Retry {
+	if retry, ok := v.any.(Retry); ok {
+		return retry
+	}
+
+	return Retry{IsRetryable: false, After: 0}
+}
+
+func (v *Value) GetSeverity() Severity {
+	if g, w := v.Kind(), KindSeverity; g != w {
+		panic(fmt.Sprintf("Value kind is %s, not %s", g, w))
+	}
+
+	return v.getSeverity()
+}
+
+func (v *Value) getSeverity() Severity {
+	if severity, ok := v.any.(Severity); ok {
+		return severity
+	}
+
+	return SeverityUnknown
+}
+
 func (v *Value) GetPublicCode() int {
 	if g, w := v.Kind(), KindPublicCode; g != w {
 		panic(fmt.Sprintf("Value kind is %s, not %s", g, w))
@@ -115,7 +166,7 @@ func (v *Value) getPublicCode() int {
 		return code
 	}
 
-	return -1
+	return ValueCodeMissing
 }
 
 func (v *Value) GetDetails() []string {
@@ -245,6 +296,8 @@ const (
 	KindScope
 	KindCode
 	KindPublicCode
+	KindRetry
+	KindSeverity
 	// MaxKindValue - used as size of array of Value. !!!PLZ do not touch this constant.
 	// This constant must be last in order of Kind constants.
 	// Usage example in `valuedError` struct...
@@ -255,6 +308,8 @@ const (
 	KindScopeName      = "kind_scope"
 	KindCodeName       = "kind_code"
 	KindPublicCodeName = "kind_public_code"
+	KindRetryName      = "kind_retry"
+	KindSeverityName   = "kind_severity"
 )
 
 func (k Kind) String() string {
@@ -269,7 +324,15 @@ func (k Kind) String() string {
 		return KindCodeName
 	case KindPublicCode:
 		return KindPublicCodeName
+	case KindRetry:
+		return KindRetryName
+	case KindSeverity:
+		return KindSeverityName
 	default:
+		if descriptor, isRegistered := customKinds.descriptor(k); isRegistered {
+			return descriptor.name
+		}
+
 		return KinaEmptyName
 	}
 }
@@ -286,7 +349,26 @@ func (k Kind) Bits() Bits {
 		return ValueCodeIsSet
 	case KindPublicCode:
 		return ValuePublicCodeIsSet
+	case KindRetry:
+		return ValueRetryIsSet
+	case KindSeverity:
+		return ValueSeverityIsSet
 	default:
+		if _, isRegistered := customKinds.descriptor(k); isRegistered {
+			return 1 << (k - 1)
+		}
+
 		return 0
 	}
 }
+
+func (k Kind) isCustom() bool {
+	return k > MaxKindValue
+}
+
+// ValueAs returns value of Value converted to T type. Can be used for values of custom kinds...
+func ValueAs[T any](v Value) (T, bool) {
+	typedValue, ok := v.any.(T)
+
+	return typedValue, ok
+}
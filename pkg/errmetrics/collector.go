@@ -0,0 +1,329 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+// Package errmetrics provides in-memory collector of counts and rates of valued errors by scope, code
+// and severity. Each error is counted once: by event of error origin, labels of error are updated
+// on re-wrap by formatters, e.g. on attach of code by ErrorWithCode...
+package errmetrics
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+const (
+	DefaultNamespace  = "errfmt"
+	DefaultRateWindow = time.Minute
+)
+
+// Clock - source of current time for rates of errors, can be replaced in tests...
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+// Now returns current system time...
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Option is optional setting of collector...
+type Option func(c *Collector)
+
+// WithNamespace sets prefix of metric names, e.g. wallet_errors_total...
+func WithNamespace(namespace string) Option {
+	if namespace == "" {
+		panic("errfmt: metrics namespace must be not empty")
+	}
+
+	return func(c *Collector) {
+		c.namespace = namespace
+	}
+}
+
+// WithRateWindow sets time window of rates of errors, window is rounded down to seconds...
+func WithRateWindow(window time.Duration) Option {
+	if window < time.Second {
+		panic("errfmt: rate window must be one second or more")
+	}
+
+	return func(c *Collector) {
+		c.window = int64(window / time.Second)
+	}
+}
+
+// WithClock sets source of current time...
+func WithClock(clock Clock) Option {
+	return func(c *Collector) {
+		c.clock = clock
+	}
+}
+
+// Key - labels of error metrics...
+type Key struct {
+	Scope    string
+	Code     int
+	Severity errformatter.Severity
+}
+
+// Sample - metrics of errors with same labels...
+type Sample struct {
+	Key
+	// Total - count of errors since creation of collector
+	Total uint64
+	// Rate - count of errors per second in rate window
+	Rate float64
+}
+
+// bucket - count of errors in one second of rate window...
+type bucket struct {
+	second int64
+	count  uint64
+}
+
+type series struct {
+	total   uint64
+	buckets []bucket
+}
+
+// Collector - in-memory collector of counts and rates of errors by scope, code and severity.
+// Collector.Observe method is errformatter.Observer, see Register receiver-method...
+type Collector struct {
+	mu sync.RWMutex
+
+	namespace string
+	window    int64
+	clock     Clock
+	series    map[Key]*series
+}
+
+// NewCollector returns new collector of error metrics...
+func NewCollector(opts ...Option) *Collector {
+	c := &Collector{
+		mu:        sync.RWMutex{},
+		namespace: DefaultNamespace,
+		window:    int64(DefaultRateWindow / time.Second),
+		clock:     systemClock{},
+		series:    make(map[Key]*series),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Register registers collector as observer of construction of valued errors.
+// Returns function, which unregisters collector...
+func (c *Collector) Register() func() {
+	return errformatter.RegisterObserver(c.Observe)
+}
+
+// Observe counts error of given event. Error is counted once by event of error origin. Event of re-wrap
+// moves count of error from labels of re-wrapped error to new labels, e.g. error created by NewError and
+// re-wrapped by ErrorWithCode is counted once with code. Re-wrap of error, which was not counted,
+// e.g. error created before registration of collector or restored from json, is counted as new error...
+func (c *Collector) Observe(event errformatter.Event) {
+	key := Key{
+		Scope:    event.Scope,
+		Code:     event.Code,
+		Severity: event.Severity,
+	}
+
+	if !event.IsRewrap {
+		c.Add(key)
+
+		return
+	}
+
+	previous := Key{
+		Scope:    event.PreviousScope,
+		Code:     event.PreviousCode,
+		Severity: event.PreviousSeverity,
+	}
+
+	if previous != key {
+		c.move(previous, key)
+	}
+}
+
+// Add counts one error with given labels...
+func (c *Collector) Add(key Key) {
+	second := c.clock.Now().Unix()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.add(key, second)
+}
+
+// move moves count of one error from previous labels to new labels...
+func (c *Collector) move(previous Key, key Key) {
+	second := c.clock.Now().Unix()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current, isExists := c.series[previous]
+	if isExists {
+		current.total--
+		c.removeLast(current, second)
+
+		if current.total == 0 {
+			delete(c.series, previous)
+		}
+	}
+
+	c.add(key, second)
+}
+
+func (c *Collector) add(key Key, second int64) {
+	current, isExists := c.series[key]
+	if !isExists {
+		current = &series{
+			total:   0,
+			buckets: make([]bucket, c.window),
+		}
+		c.series[key] = current
+	}
+
+	current.total++
+
+	last := &current.buckets[second%c.window]
+	if last.second != second {
+		last.second = second
+		last.count = 0
+	}
+
+	last.count++
+}
+
+// removeLast removes one error from latest not empty bucket of rate window...
+func (c *Collector) removeLast(current *series, now int64) {
+	var latest *bucket
+
+	for i := range current.buckets {
+		candidate := &current.buckets[i]
+		if candidate.count == 0 || candidate.second <= now-c.window || candidate.second > now {
+			continue
+		}
+
+		if latest == nil || candidate.second > latest.second {
+			latest = candidate
+		}
+	}
+
+	if latest != nil {
+		latest.count--
+	}
+}
+
+// Count returns count of errors with given labels since creation of collector...
+func (c *Collector) Count(key Key) uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	current, isExists := c.series[key]
+	if !isExists {
+		return 0
+	}
+
+	return current.total
+}
+
+// Rate returns count of errors with given labels per second in rate window...
+func (c *Collector) Rate(key Key) float64 {
+	now := c.clock.Now().Unix()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	current, isExists := c.series[key]
+	if !isExists {
+		return 0
+	}
+
+	return c.rate(current, now)
+}
+
+func (c *Collector) rate(current *series, now int64) float64 {
+	var count uint64
+
+	for i := range current.buckets {
+		if current.buckets[i].second > now-c.window && current.buckets[i].second <= now {
+			count += current.buckets[i].count
+		}
+	}
+
+	return float64(count) / float64(c.window)
+}
+
+// Snapshot returns metrics of all observed errors, sorted by scope, code and severity...
+func (c *Collector) Snapshot() []Sample {
+	now := c.clock.Now().Unix()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	samples := make([]Sample, 0, len(c.series))
+	for key, current := range c.series {
+		samples = append(samples, Sample{
+			Key:   key,
+			Total: current.total,
+			Rate:  c.rate(current, now),
+		})
+	}
+
+	slices.SortFunc(samples, func(a, b Sample) int {
+		return cmp.Or(
+			cmp.Compare(a.Scope, b.Scope),
+			cmp.Compare(a.Code, b.Code),
+			cmp.Compare(a.Severity, b.Severity),
+		)
+	})
+
+	return samples
+}
+
+// Reset removes metrics of all observed errors...
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.series = make(map[Key]*series)
+}
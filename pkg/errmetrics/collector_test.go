@@ -0,0 +1,218 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errmetrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func TestCollector(t *testing.T) {
+	t.Run("count and rate of errors in rate window", func(t *testing.T) {
+		clock := &testClock{now: time.Unix(1_000, 0)}
+		collector := NewCollector(WithClock(clock), WithRateWindow(10*time.Second))
+
+		key := Key{
+			Scope:    "wallet",
+			Code:     1042,
+			Severity: errformatter.SeverityError,
+		}
+
+		for range 5 {
+			collector.Add(key)
+		}
+
+		clock.now = clock.now.Add(5 * time.Second)
+		collector.Add(key)
+
+		if count := collector.Count(key); count != 6 {
+			t.Errorf("count not equal with expected. current: %d, expected: %d", count, 6)
+		}
+
+		if rate := collector.Rate(key); rate != 0.6 {
+			t.Errorf("rate not equal with expected. current: %v, expected: %v", rate, 0.6)
+		}
+
+		// first five errors are out of rate window
+		clock.now = clock.now.Add(7 * time.Second)
+
+		if rate := collector.Rate(key); rate != 0.1 {
+			t.Errorf("rate not equal with expected. current: %v, expected: %v", rate, 0.1)
+		}
+
+		if count := collector.Count(key); count != 6 {
+			t.Errorf("count not equal with expected. current: %d, expected: %d", count, 6)
+		}
+	})
+
+	t.Run("registered collector - error with code counted once per call", func(t *testing.T) {
+		collector := NewCollector()
+		t.Cleanup(collector.Register())
+
+		svc := errformatter.New(
+			errformatter.WithValues(errformatter.NewValue(errformatter.KindScope, "collector_scope")),
+			errformatter.WithSeverity(errformatter.SeverityWarning))
+
+		_ = svc.ErrorWithCode(errors.New("test error"), 1042)
+
+		key := Key{
+			Scope:    "collector_scope",
+			Code:     1042,
+			Severity: errformatter.SeverityWarning,
+		}
+
+		if count := collector.Count(key); count != 1 {
+			t.Errorf("count not equal with expected. current: %d, expected: %d", count, 1)
+		}
+	})
+
+	t.Run("registered collector - re-wrapped error counted once with final labels", func(t *testing.T) {
+		collector := NewCollector()
+		t.Cleanup(collector.Register())
+
+		svc := errformatter.New(
+			errformatter.WithValues(errformatter.NewValue(errformatter.KindScope, "rewrap_scope")))
+
+		err := svc.ErrorWithCode(svc.NewError("boom"), 404)
+		_ = svc.Error(err, "detail_1")
+
+		noCodeKey := Key{
+			Scope:    "rewrap_scope",
+			Code:     errformatter.ValueCodeMissing,
+			Severity: errformatter.SeverityUnknown,
+		}
+
+		if count := collector.Count(noCodeKey); count != 0 {
+			t.Errorf("count not equal with expected. current: %d, expected: %d", count, 0)
+		}
+
+		codeKey := noCodeKey
+		codeKey.Code = 404
+
+		if count := collector.Count(codeKey); count != 1 {
+			t.Errorf("count not equal with expected. current: %d, expected: %d", count, 1)
+		}
+
+		if rate := collector.Rate(codeKey); rate != 1/float64(DefaultRateWindow/time.Second) {
+			t.Errorf("rate not equal with expected. current: %f", rate)
+		}
+
+		if snapshot := collector.Snapshot(); len(snapshot) != 1 {
+			t.Errorf("count of samples not equal with expected. current: %d, expected: %d", len(snapshot), 1)
+		}
+	})
+
+	t.Run("registered collector - re-wrap of not counted error counted as new error", func(t *testing.T) {
+		svc := errformatter.New(
+			errformatter.WithValues(errformatter.NewValue(errformatter.KindScope, "not_counted_scope")))
+
+		err := svc.NewError("boom")
+
+		collector := NewCollector()
+		t.Cleanup(collector.Register())
+
+		_ = svc.ErrorWithCode(err, 404)
+
+		key := Key{
+			Scope:    "not_counted_scope",
+			Code:     404,
+			Severity: errformatter.SeverityUnknown,
+		}
+
+		if count := collector.Count(key); count != 1 {
+			t.Errorf("count not equal with expected. current: %d, expected: %d", count, 1)
+		}
+	})
+
+	t.Run("registered collector and catalog - error with code counted once", func(t *testing.T) {
+		collector := NewCollector()
+		t.Cleanup(collector.Register())
+
+		catalog := errformatter.NewCatalog().MustRegister(errformatter.CodeInfo{
+			Code:        1043,
+			Name:        "collector_conflict",
+			Description: "",
+			PublicCode:  409,
+			HTTPStatus:  0,
+			GRPCCode:    0,
+			Severity:    errformatter.SeverityCritical,
+		})
+
+		svc := errformatter.New(errformatter.WithCatalog(catalog), errformatter.WithScope("catalog_scope"))
+
+		_ = svc.ErrorWithCode(errors.New("test error"), 1043)
+
+		snapshot := collector.Snapshot()
+		if len(snapshot) != 1 {
+			t.Fatalf("count of samples not equal with expected. current: %d, expected: %d", len(snapshot), 1)
+		}
+
+		expectedKey := Key{
+			Scope:    "catalog_scope",
+			Code:     1043,
+			Severity: errformatter.SeverityCritical,
+		}
+
+		if snapshot[0].Key != expectedKey || snapshot[0].Total != 1 {
+			t.Errorf("sample not equal with expected. current: %+v, expected: %+v", snapshot[0], expectedKey)
+		}
+	})
+
+	t.Run("reset - metrics are removed", func(t *testing.T) {
+		collector := NewCollector()
+
+		key := Key{
+			Scope:    "wallet",
+			Code:     1042,
+			Severity: errformatter.SeverityError,
+		}
+
+		collector.Add(key)
+		collector.Reset()
+
+		if samples := collector.Snapshot(); len(samples) != 0 {
+			t.Errorf("count of samples not equal with expected. current: %d, expected: %d", len(samples), 0)
+		}
+	})
+}
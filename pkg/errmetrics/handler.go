@@ -0,0 +1,99 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errmetrics
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+// ContentTypeTextExposition - content type of Prometheus text exposition format...
+const ContentTypeTextExposition = "text/plain; version=0.0.4; charset=utf-8"
+
+var _ http.Handler = (*Collector)(nil)
+
+//nolint:gochecknoglobals // it's ok - replacer is immutable
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// ServeHTTP writes metrics of errors in Prometheus text exposition format - counter <namespace>_errors_total
+// and gauge <namespace>_errors_rate with scope, code and severity labels...
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentTypeTextExposition)
+	w.WriteHeader(http.StatusOK)
+
+	_ = c.WriteText(w)
+}
+
+// WriteText writes metrics of errors in Prometheus text exposition format...
+func (c *Collector) WriteText(w io.Writer) error {
+	samples := c.Snapshot()
+	buf := bufio.NewWriter(w)
+
+	totalName := c.namespace + "_errors_total"
+	_, _ = buf.WriteString("# HELP " + totalName + " Count of errors by scope, code and severity.\n")
+	_, _ = buf.WriteString("# TYPE " + totalName + " counter\n")
+
+	for i := range samples {
+		_, _ = buf.WriteString(totalName + formatLabels(samples[i].Key) + " " +
+			strconv.FormatUint(samples[i].Total, 10) + "\n")
+	}
+
+	rateName := c.namespace + "_errors_rate"
+	_, _ = buf.WriteString("# HELP " + rateName +
+		" Count of errors per second in rate window by scope, code and severity.\n")
+	_, _ = buf.WriteString("# TYPE " + rateName + " gauge\n")
+
+	for i := range samples {
+		_, _ = buf.WriteString(rateName + formatLabels(samples[i].Key) + " " +
+			strconv.FormatFloat(samples[i].Rate, 'g', -1, 64) + "\n")
+	}
+
+	//nolint:wrapcheck // it's ok - errors of writer must be returned as is
+	return buf.Flush()
+}
+
+// formatLabels returns labels of sample. Code label is empty for errors without code...
+func formatLabels(key Key) string {
+	code := ""
+	if key.Code != errformatter.ValueCodeMissing {
+		code = strconv.Itoa(key.Code)
+	}
+
+	return `{scope="` + labelValueReplacer.Replace(key.Scope) + `",code="` + code +
+		`",severity="` + key.Severity.String() + `"}`
+}
@@ -0,0 +1,93 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errmetrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+func TestCollector_ServeHTTP(t *testing.T) {
+	t.Run("metrics in text exposition format", func(t *testing.T) {
+		const expectedBody = `# HELP wallet_errors_total Count of errors by scope, code and severity.
+# TYPE wallet_errors_total counter
+wallet_errors_total{scope="signer",code="",severity="unknown"} 1
+wallet_errors_total{scope="wallet",code="1042",severity="error"} 2
+wallet_errors_total{scope="wallet \"main\"",code="1042",severity="critical"} 1
+# HELP wallet_errors_rate Count of errors per second in rate window by scope, code and severity.
+# TYPE wallet_errors_rate gauge
+wallet_errors_rate{scope="signer",code="",severity="unknown"} 0.25
+wallet_errors_rate{scope="wallet",code="1042",severity="error"} 0.5
+wallet_errors_rate{scope="wallet \"main\"",code="1042",severity="critical"} 0.25
+`
+
+		collector := NewCollector(
+			WithNamespace("wallet"),
+			WithRateWindow(4*time.Second),
+			WithClock(&testClock{now: time.Unix(1_000, 0)}))
+
+		collector.Add(Key{Scope: "wallet", Code: 1042, Severity: errformatter.SeverityError})
+		collector.Add(Key{Scope: "wallet", Code: 1042, Severity: errformatter.SeverityError})
+		collector.Add(Key{Scope: `wallet "main"`, Code: 1042, Severity: errformatter.SeverityCritical})
+		collector.Add(Key{Scope: "signer", Code: errformatter.ValueCodeMissing, Severity: errformatter.SeverityUnknown})
+
+		server := httptest.NewServer(collector)
+		t.Cleanup(server.Close)
+
+		resp, err := http.Get(server.URL) //nolint:noctx // it's ok - request of test server
+		if err != nil {
+			t.Fatalf("unable to get metrics: %s", err)
+		}
+
+		defer resp.Body.Close()
+
+		if contentType := resp.Header.Get("Content-Type"); contentType != ContentTypeTextExposition {
+			t.Errorf("content type not equal with expected. current: %s, expected: %s",
+				contentType, ContentTypeTextExposition)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("unable to read metrics: %s", err)
+		}
+
+		if string(body) != expectedBody {
+			t.Errorf("metrics not equal with expected. current: %s, expected: %s", body, expectedBody)
+		}
+	})
+}
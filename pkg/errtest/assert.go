@@ -0,0 +1,146 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errtest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+const goldenFilePerm = 0o600
+
+// isGoldenUpdate - rewrite golden files by AssertGolden instead of comparison, go test ./... -errtest.update
+//
+//nolint:gochecknoglobals // it's ok - flag must be registered once on package import
+var isGoldenUpdate = flag.Bool("errtest.update", false, "update golden files of errtest.AssertGolden")
+
+// AssertCode checks code of valued error...
+func AssertCode(t testing.TB, err error, code int) {
+	t.Helper()
+
+	if current := errformatter.ValuedErrorGetCode(err); current != code {
+		t.Errorf("error code not equal with expected. current: %d, expected: %d", current, code)
+	}
+}
+
+// AssertPublicCode checks public code of valued error...
+func AssertPublicCode(t testing.TB, err error, publicCode int) {
+	t.Helper()
+
+	if current := errformatter.ValuedErrorGetPublicCode(err); current != publicCode {
+		t.Errorf("error public code not equal with expected. current: %d, expected: %d", current, publicCode)
+	}
+}
+
+// AssertScope checks scope of valued error...
+func AssertScope(t testing.TB, err error, scope string) {
+	t.Helper()
+
+	value, isExists := errformatter.ValuedErrorGetValue(err, errformatter.KindScope)
+	if !isExists {
+		t.Errorf("error scope not exists. expected: %s", scope)
+
+		return
+	}
+
+	if current := value.GetScope(); current != scope {
+		t.Errorf("error scope not equal with expected. current: %s, expected: %s", current, scope)
+	}
+}
+
+// AssertDetails checks details list of valued error, order of details is important...
+func AssertDetails(t testing.TB, err error, details ...string) {
+	t.Helper()
+
+	value, isExists := errformatter.ValuedErrorGetValue(err, errformatter.KindDetails)
+	if !isExists {
+		if len(details) > 0 {
+			t.Errorf("error details not exists. expected: %q", details)
+		}
+
+		return
+	}
+
+	if current := value.GetDetails(); !slices.Equal(current, details) {
+		t.Errorf("error details not equal with expected. current: %q, expected: %q", current, details)
+	}
+}
+
+// AssertChainContains checks that target error is in cause chain of error, same as errors.Is...
+func AssertChainContains(t testing.TB, err error, target error) {
+	t.Helper()
+
+	if !errors.Is(err, target) {
+		t.Errorf("error chain not contains target error. current: %v, target: %v", err, target)
+	}
+}
+
+// AssertGolden compares %+v rendering of error with content of golden file.
+// Golden file is rewritten instead of comparison if test is run with -errtest.update flag...
+func AssertGolden(t testing.TB, err error, path string) {
+	t.Helper()
+
+	current := fmt.Sprintf("%+v", err)
+
+	if *isGoldenUpdate {
+		writeErr := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		if writeErr == nil {
+			writeErr = os.WriteFile(path, []byte(current), goldenFilePerm)
+		}
+
+		if writeErr != nil {
+			t.Fatalf("unable to update golden file %s: %s", path, writeErr)
+		}
+
+		return
+	}
+
+	expected, readErr := os.ReadFile(path)
+	if readErr != nil {
+		t.Fatalf("unable to read golden file %s: %s", path, readErr)
+
+		return
+	}
+
+	if current != string(expected) {
+		t.Errorf("error report not equal with golden file %s. current:\n%s\nexpected:\n%s",
+			path, current, expected)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errtest
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+// recordingT - testing.TB, which records failures of assertions instead of test failure...
+type recordingT struct {
+	testing.TB
+
+	failures []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Fatalf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func newTestError() error {
+	svc := errformatter.NewValuesErrorFormatter(
+		errformatter.NewValue(errformatter.KindScope, "wallet_signer"),
+		errformatter.NewValue(errformatter.KindPublicCode, 1042),
+	)
+
+	return svc.ErrorWithCode(svc.Error(errTestKeyNotFound, "detail_1", "detail_2"), 404)
+}
+
+var errTestKeyNotFound = errors.New("key not found")
+
+func TestAssertions(t *testing.T) {
+	t.Run("assertions - matched error", func(t *testing.T) {
+		err := newTestError()
+
+		AssertCode(t, err, 404)
+		AssertPublicCode(t, err, 1042)
+		AssertScope(t, err, "wallet_signer")
+		AssertDetails(t, err, "detail_1", "detail_2")
+		AssertChainContains(t, fmt.Errorf("outer: %w", err), errTestKeyNotFound)
+	})
+
+	t.Run("assertions - not matched error", func(t *testing.T) {
+		const expectedFailuresCount = 6
+
+		recorder := &recordingT{TB: t, failures: nil}
+		err := newTestError()
+
+		AssertCode(recorder, err, 500)
+		AssertPublicCode(recorder, err, 1)
+		AssertScope(recorder, err, "other_scope")
+		AssertScope(recorder, errors.New("plain error"), "wallet_signer")
+		AssertDetails(recorder, err, "detail_2", "detail_1")
+		AssertChainContains(recorder, err, errors.New("key not found"))
+
+		if len(recorder.failures) != expectedFailuresCount {
+			t.Errorf("failures count not equal with expected. current: %d, expected: %d. failures: %q",
+				len(recorder.failures), expectedFailuresCount, recorder.failures)
+		}
+	})
+
+	t.Run("golden file - %+v report of error", func(t *testing.T) {
+		AssertGolden(t, newTestError(), filepath.Join("testdata", "valued_error.golden"))
+
+		if *isGoldenUpdate {
+			return
+		}
+
+		recorder := &recordingT{TB: t, failures: nil}
+
+		AssertGolden(recorder, errors.New("other error"), filepath.Join("testdata", "valued_error.golden"))
+		AssertGolden(recorder, errors.New("other error"), filepath.Join("testdata", "not_exists.golden"))
+
+		if len(recorder.failures) != 2 {
+			t.Errorf("failures count not equal with expected. current: %d, expected: %d",
+				len(recorder.failures), 2)
+		}
+	})
+}
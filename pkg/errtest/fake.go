@@ -0,0 +1,282 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errtest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+// Call - call of formatter method, recorded by Fake formatter...
+type Call struct {
+	// Method - name of called method, e.g. ErrorWithCode
+	Method string
+	// Args - arguments of call in order of method signature, variadic arguments are passed as slice
+	Args []any
+}
+
+// recorder - concurrent-safe list of calls, shared by fake formatter and all child formatters...
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ errformatter.Formatter = (*Fake)(nil)
+
+// Fake - formatter, which records every call of formatter methods. Errors are built by real formatter
+// service, so code under test gets same errors as in production...
+type Fake struct {
+	recorder *recorder
+	next     errformatter.Formatter
+}
+
+// NewFake returns fake formatter, errors are built by formatter service, created by errformatter.New
+// function with given options...
+func NewFake(opts ...errformatter.Option) *Fake {
+	return &Fake{
+		recorder: &recorder{
+			mu:    sync.Mutex{},
+			calls: make([]Call, 0),
+		},
+		next: errformatter.New(opts...),
+	}
+}
+
+// Calls returns copy of list of recorded calls of fake formatter and all child formatters...
+func (f *Fake) Calls() []Call {
+	f.recorder.mu.Lock()
+	defer f.recorder.mu.Unlock()
+
+	calls := make([]Call, len(f.recorder.calls))
+	copy(calls, f.recorder.calls)
+
+	return calls
+}
+
+// CallsOf returns recorded calls of method with given name...
+func (f *Fake) CallsOf(method string) []Call {
+	f.recorder.mu.Lock()
+	defer f.recorder.mu.Unlock()
+
+	calls := make([]Call, 0)
+
+	for i := range f.recorder.calls {
+		if f.recorder.calls[i].Method == method {
+			calls = append(calls, f.recorder.calls[i])
+		}
+	}
+
+	return calls
+}
+
+// Reset clears list of recorded calls...
+func (f *Fake) Reset() {
+	f.recorder.mu.Lock()
+	defer f.recorder.mu.Unlock()
+
+	f.recorder.calls = make([]Call, 0)
+}
+
+func (f *Fake) record(method string, args ...any) {
+	f.recorder.mu.Lock()
+	defer f.recorder.mu.Unlock()
+
+	f.recorder.calls = append(f.recorder.calls, Call{
+		Method: method,
+		Args:   args,
+	})
+}
+
+func (f *Fake) ErrorWithCode(err error, code int) error {
+	f.record("ErrorWithCode", err, code)
+
+	return f.next.ErrorWithCode(err, code)
+}
+
+func (f *Fake) ErrWithCode(err error, code int) error {
+	f.record("ErrWithCode", err, code)
+
+	return f.next.ErrWithCode(err, code)
+}
+
+func (f *Fake) ErrorGetCode(err error) int {
+	f.record("ErrorGetCode", err)
+
+	return f.next.ErrorGetCode(err)
+}
+
+func (f *Fake) ErrGetCode(err error) int {
+	f.record("ErrGetCode", err)
+
+	return f.next.ErrGetCode(err)
+}
+
+func (f *Fake) ErrorWithPublicCode(err error, publicCode int) error {
+	f.record("ErrorWithPublicCode", err, publicCode)
+
+	return f.next.ErrorWithPublicCode(err, publicCode)
+}
+
+func (f *Fake) ErrWithPublicCode(err error, publicCode int) error {
+	f.record("ErrWithPublicCode", err, publicCode)
+
+	return f.next.ErrWithPublicCode(err, publicCode)
+}
+
+func (f *Fake) ErrorGetPublicCode(err error) int {
+	f.record("ErrorGetPublicCode", err)
+
+	return f.next.ErrorGetPublicCode(err)
+}
+
+func (f *Fake) ErrGetPublicCode(err error) int {
+	f.record("ErrGetPublicCode", err)
+
+	return f.next.ErrGetPublicCode(err)
+}
+
+func (f *Fake) ErrorWithRetry(err error, isRetryable bool) error {
+	f.record("ErrorWithRetry", err, isRetryable)
+
+	return f.next.ErrorWithRetry(err, isRetryable)
+}
+
+func (f *Fake) ErrWithRetry(err error, isRetryable bool) error {
+	f.record("ErrWithRetry", err, isRetryable)
+
+	return f.next.ErrWithRetry(err, isRetryable)
+}
+
+func (f *Fake) ErrorWithRetryAfter(err error, after time.Duration) error {
+	f.record("ErrorWithRetryAfter", err, after)
+
+	return f.next.ErrorWithRetryAfter(err, after)
+}
+
+func (f *Fake) ErrWithRetryAfter(err error, after time.Duration) error {
+	f.record("ErrWithRetryAfter", err, after)
+
+	return f.next.ErrWithRetryAfter(err, after)
+}
+
+func (f *Fake) ErrorWithSeverity(err error, severity errformatter.Severity) error {
+	f.record("ErrorWithSeverity", err, severity)
+
+	return f.next.ErrorWithSeverity(err, severity)
+}
+
+func (f *Fake) ErrWithSeverity(err error, severity errformatter.Severity) error {
+	f.record("ErrWithSeverity", err, severity)
+
+	return f.next.ErrWithSeverity(err, severity)
+}
+
+func (f *Fake) ErrorNoWrap(err error) error {
+	f.record("ErrorNoWrap", err)
+
+	return f.next.ErrorNoWrap(err)
+}
+
+func (f *Fake) ErrNoWrap(err error) error {
+	f.record("ErrNoWrap", err)
+
+	return f.next.ErrNoWrap(err)
+}
+
+func (f *Fake) ErrorOnly(err error, details ...string) error {
+	f.record("ErrorOnly", err, details)
+
+	return f.next.ErrorOnly(err, details...)
+}
+
+func (f *Fake) Error(err error, details ...string) error {
+	f.record("Error", err, details)
+
+	return f.next.Error(err, details...)
+}
+
+func (f *Fake) Errorf(err error, format string, args ...interface{}) error {
+	f.record("Errorf", err, format, args)
+
+	return f.next.Errorf(err, format, args...)
+}
+
+func (f *Fake) NewError(details ...string) error {
+	f.record("NewError", details)
+
+	return f.next.NewError(details...)
+}
+
+func (f *Fake) NewErrorf(format string, args ...interface{}) error {
+	f.record("NewErrorf", format, args)
+
+	return f.next.NewErrorf(format, args...)
+}
+
+func (f *Fake) ErrorCtx(ctx context.Context, err error, details ...string) error {
+	f.record("ErrorCtx", ctx, err, details)
+
+	return f.next.ErrorCtx(ctx, err, details...)
+}
+
+func (f *Fake) ErrorfCtx(ctx context.Context, err error, format string, args ...interface{}) error {
+	f.record("ErrorfCtx", ctx, err, format, args)
+
+	return f.next.ErrorfCtx(ctx, err, format, args...)
+}
+
+func (f *Fake) NewErrorCtx(ctx context.Context, details ...string) error {
+	f.record("NewErrorCtx", ctx, details)
+
+	return f.next.NewErrorCtx(ctx, details...)
+}
+
+func (f *Fake) NewErrorfCtx(ctx context.Context, format string, args ...interface{}) error {
+	f.record("NewErrorfCtx", ctx, format, args)
+
+	return f.next.NewErrorfCtx(ctx, format, args...)
+}
+
+// WithScope returns child fake formatter of child formatter service, calls of child formatter
+// are recorded to same calls list...
+func (f *Fake) WithScope(scope string) errformatter.Formatter {
+	f.record("WithScope", scope)
+
+	return &Fake{
+		recorder: f.recorder,
+		next:     f.next.WithScope(scope),
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package errtest
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestFake(t *testing.T) {
+	t.Run("fake - calls are recorded and errors are built by formatter", func(t *testing.T) {
+		const expectedResult = "key not found -> detail_1"
+
+		fake := NewFake()
+
+		err := fake.ErrorWithCode(fake.Error(errTestKeyNotFound, "detail_1"), 404)
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s", err.Error(), expectedResult)
+		}
+
+		AssertCode(t, err, 404)
+
+		calls := fake.Calls()
+		if len(calls) != 2 || calls[0].Method != "Error" || calls[1].Method != "ErrorWithCode" {
+			t.Fatalf("calls not equal with expected. current: %+v", calls)
+		}
+
+		if details, _ := calls[0].Args[1].([]string); len(details) != 1 || details[0] != "detail_1" {
+			t.Errorf("call args not equal with expected. current: %+v", calls[0].Args)
+		}
+
+		if code, _ := calls[1].Args[1].(int); code != 404 {
+			t.Errorf("call args not equal with expected. current: %+v", calls[1].Args)
+		}
+
+		fake.Reset()
+
+		if calls = fake.Calls(); len(calls) != 0 {
+			t.Errorf("calls must be cleared. current: %+v", calls)
+		}
+	})
+
+	t.Run("fake - calls of child formatters are recorded", func(t *testing.T) {
+		const expectedResult = "wallet/signer: key not found -> detail_1"
+
+		fake := NewFake()
+		child := fake.WithScope("wallet").WithScope("signer")
+
+		err := child.ErrorCtx(context.Background(), errTestKeyNotFound, "detail_1")
+
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s", err.Error(), expectedResult)
+		}
+
+		AssertChainContains(t, err, errTestKeyNotFound)
+
+		if calls := fake.CallsOf("WithScope"); len(calls) != 2 {
+			t.Errorf("calls count not equal with expected. current: %d, expected: %d", len(calls), 2)
+		}
+
+		if calls := fake.CallsOf("ErrorCtx"); len(calls) != 1 {
+			t.Errorf("calls count not equal with expected. current: %d, expected: %d", len(calls), 1)
+		}
+	})
+
+	t.Run("fake - concurrent calls", func(t *testing.T) {
+		const callsCount = 32
+
+		fake := NewFake()
+
+		var wg sync.WaitGroup
+
+		for range callsCount {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_ = fake.NewErrorf("test error %d", 1)
+			}()
+		}
+
+		wg.Wait()
+
+		if calls := fake.CallsOf("NewErrorf"); len(calls) != callsCount {
+			t.Errorf("calls count not equal with expected. current: %d, expected: %d", len(calls), callsCount)
+		}
+	})
+}
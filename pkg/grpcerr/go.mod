@@ -0,0 +1,18 @@
+module github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/grpcerr
+
+go 1.22.0
+
+require (
+	github.com/crypto-bundle/bc-wallet-common-lib-errors v0.0.0-00010101000000-000000000000
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241015192408-796eee8c2d53
+	google.golang.org/grpc v1.69.4
+)
+
+require (
+	golang.org/x/net v0.30.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
+	golang.org/x/text v0.19.0 // indirect
+	google.golang.org/protobuf v1.35.1 // indirect
+)
+
+replace github.com/crypto-bundle/bc-wallet-common-lib-errors => ../..
@@ -0,0 +1,262 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package grpcerr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+const (
+	testErrorCode       = 100500
+	testErrorPublicCode = 1042
+	testErrorScope      = "wallet_signer"
+	testErrorText       = "wallet_signer: key not found -> detail_1, detail_2"
+)
+
+var (
+	errTestKeyNotFound = errors.New("key not found")
+	// errTestKeyNotFoundSentinel - matched by scope and code of restored error, identity is lost on gRPC boundary
+	errTestKeyNotFoundSentinel = errformatter.Define(testErrorScope, testErrorCode, "key not found")
+)
+
+type testHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+}
+
+func (s *testHealthServer) newError() error {
+	svc := errformatter.NewValuesErrorFormatter(
+		errformatter.NewValue(errformatter.KindScope, testErrorScope),
+		errformatter.NewValue(errformatter.KindPublicCode, testErrorPublicCode),
+	)
+
+	return svc.ErrorWithCode(svc.Error(errTestKeyNotFound, "detail_1", "detail_2"), testErrorCode)
+}
+
+func (s *testHealthServer) Check(context.Context,
+	*grpc_health_v1.HealthCheckRequest,
+) (*grpc_health_v1.HealthCheckResponse, error) {
+	return nil, s.newError()
+}
+
+func (s *testHealthServer) Watch(*grpc_health_v1.HealthCheckRequest,
+	grpc.ServerStreamingServer[grpc_health_v1.HealthCheckResponse],
+) error {
+	return s.newError()
+}
+
+func newTestClient(t *testing.T) grpc_health_v1.HealthClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(WithCodeStatus(testErrorCode, codes.NotFound))),
+		grpc.StreamInterceptor(StreamServerInterceptor(WithCodeStatus(testErrorCode, codes.NotFound))),
+	)
+	grpc_health_v1.RegisterHealthServer(server, &testHealthServer{})
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatalf("unable to create client connection: %s", err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+
+		server.Stop()
+	})
+
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func assertRestoredError(t *testing.T, err error) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("error must be returned")
+	}
+
+	if err.Error() != testErrorText {
+		t.Errorf("error text not equal with expected. current: %s, expected: %s",
+			err.Error(), testErrorText)
+	}
+
+	if code := status.Code(err); code != codes.NotFound {
+		t.Errorf("status code not equal with expected. current: %s, expected: %s",
+			code, codes.NotFound)
+	}
+
+	if code := errformatter.ValuedErrorGetCode(err); code != testErrorCode {
+		t.Errorf("error code not equal with expected. current: %d, expected: %d",
+			code, testErrorCode)
+	}
+
+	if publicCode := errformatter.ValuedErrorGetPublicCode(err); publicCode != testErrorPublicCode {
+		t.Errorf("error public code not equal with expected. current: %d, expected: %d",
+			publicCode, testErrorPublicCode)
+	}
+
+	scope, _ := errformatter.ValuedErrorGetValue(err, errformatter.KindScope)
+	if scope.GetScope() != testErrorScope {
+		t.Errorf("error scope not equal with expected. current: %s, expected: %s",
+			scope.GetScope(), testErrorScope)
+	}
+
+	details, _ := errformatter.ValuedErrorGetValue(err, errformatter.KindDetails)
+	if len(details.GetDetails()) != 2 {
+		t.Errorf("error details not equal with expected. current: %v", details.GetDetails())
+	}
+
+	if !errors.Is(err, errTestKeyNotFoundSentinel) {
+		t.Errorf("restored error must match sentinel error. current: %s", err)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Run("unary call - valued error restored on client side", func(t *testing.T) {
+		client := newTestClient(t)
+
+		_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+
+		assertRestoredError(t, err)
+	})
+
+	t.Run("stream call - valued error restored on client side", func(t *testing.T) {
+		client := newTestClient(t)
+
+		stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		if err != nil {
+			t.Fatalf("unexpected stream error: %s", err)
+		}
+
+		_, err = stream.Recv()
+
+		assertRestoredError(t, err)
+	})
+}
+
+func TestStatusConversion(t *testing.T) {
+	t.Run("plain error - default status code without details", func(t *testing.T) {
+		st := ToGRPCStatus(errors.New("test error"), WithDefaultStatus(codes.Internal))
+		if st.Code() != codes.Internal || len(st.Details()) != 0 {
+			t.Errorf("status not equal with expected. current: %s", st)
+		}
+
+		if err := FromGRPCStatus(st); status.Code(err) != codes.Internal {
+			t.Errorf("status code not equal with expected. current: %s", status.Code(err))
+		}
+	})
+
+	t.Run("context errors - canceled and deadline exceeded status codes", func(t *testing.T) {
+		formatter := errformatter.New(errformatter.WithValues(errformatter.NewValue(errformatter.KindScope, "ctx")))
+
+		if code := ToGRPCStatus(formatter.Error(context.Canceled, "detail_1")).Code(); code != codes.Canceled {
+			t.Errorf("status code not equal with expected. current: %s, expected: %s", code, codes.Canceled)
+		}
+
+		if code := ToGRPCStatus(context.DeadlineExceeded).Code(); code != codes.DeadlineExceeded {
+			t.Errorf("status code not equal with expected. current: %s, expected: %s", code, codes.DeadlineExceeded)
+		}
+	})
+
+	t.Run("valued error - retry and severity values restored", func(t *testing.T) {
+		formatter := errformatter.New(errformatter.WithValues(errformatter.NewValue(errformatter.KindScope, "node")))
+
+		err := formatter.ErrorWithRetryAfter(errors.New("test error"), time.Second)
+		err = formatter.ErrorWithSeverity(err, errformatter.SeverityCritical)
+
+		restoredErr := FromGRPCStatus(ToGRPCStatus(err))
+
+		if after, isSet := errformatter.RetryAfter(restoredErr); !isSet || after != time.Second {
+			t.Errorf("retry delay not equal with expected. current: %s, expected: %s", after, time.Second)
+		}
+
+		if severity := errformatter.ErrorSeverity(restoredErr); severity != errformatter.SeverityCritical {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s",
+				severity, errformatter.SeverityCritical)
+		}
+	})
+
+	t.Run("status error - status is kept as is", func(t *testing.T) {
+		st := ToGRPCStatus(status.Error(codes.PermissionDenied, "test error"))
+		if st.Code() != codes.PermissionDenied {
+			t.Errorf("status code not equal with expected. current: %s, expected: %s",
+				st.Code(), codes.PermissionDenied)
+		}
+	})
+	t.Run("wrapped status error - status code of downstream call is kept", func(t *testing.T) {
+		svc := errformatter.New(errformatter.WithValues(errformatter.NewValue(errformatter.KindScope, "gateway")),
+			errformatter.WithSeverity(errformatter.SeverityWarning))
+
+		err := svc.Error(status.Error(codes.NotFound, "wallet not found"), "detail_1")
+
+		st := ToGRPCStatus(err)
+		if st.Code() != codes.NotFound {
+			t.Errorf("status code not equal with expected. current: %s, expected: %s", st.Code(), codes.NotFound)
+		}
+
+		if severity := errformatter.ErrorSeverity(FromGRPCStatus(st)); severity != errformatter.SeverityWarning {
+			t.Errorf("severity not equal with expected. current: %s, expected: %s",
+				severity, errformatter.SeverityWarning)
+		}
+
+		st = ToGRPCStatus(svc.ErrorWithCode(err, 500), WithCodeStatus(500, codes.Internal))
+		if st.Code() != codes.Internal {
+			t.Errorf("status code not equal with expected. current: %s, expected: %s", st.Code(), codes.Internal)
+		}
+	})
+}
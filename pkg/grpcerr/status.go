@@ -0,0 +1,308 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package grpcerr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+const (
+	// ErrorInfoDomain - domain of errdetails.ErrorInfo status detail with values of valued error...
+	ErrorInfoDomain = "errformatter.bc-wallet.crypto-bundle"
+	// ErrorInfoReason - reason of errdetails.ErrorInfo status detail with values of valued error...
+	ErrorInfoReason = "VALUED_ERROR"
+
+	MetadataKeyCode       = "code"
+	MetadataKeyPublicCode = "public_code"
+	MetadataKeyScope      = "scope"
+	MetadataKeyDetails    = "details"
+	MetadataKeyRetry      = "retry"
+	MetadataKeySeverity   = "severity"
+)
+
+// Option is optional setting of status conversion...
+type Option func(c *converter)
+
+// WithCodeStatus sets gRPC status code for errors with given error code...
+func WithCodeStatus(code int, statusCode codes.Code) Option {
+	return func(c *converter) {
+		c.statusByCode[code] = statusCode
+	}
+}
+
+// WithStatusMapping sets gRPC status codes for errors by error codes...
+func WithStatusMapping(mapping map[int]codes.Code) Option {
+	return func(c *converter) {
+		for code, statusCode := range mapping {
+			c.statusByCode[code] = statusCode
+		}
+	}
+}
+
+// WithCatalog sets catalog of error codes. gRPC status code of not mapped code is taken from catalog...
+func WithCatalog(catalog *errformatter.Catalog) Option {
+	return func(c *converter) {
+		c.catalog = catalog
+	}
+}
+
+// WithDefaultStatus sets gRPC status code for errors without code or with not mapped code.
+// Errors of context cancellation and deadline are converted to Canceled and DeadlineExceeded status codes...
+func WithDefaultStatus(statusCode codes.Code) Option {
+	return func(c *converter) {
+		c.defaultStatus = statusCode
+	}
+}
+
+type converter struct {
+	statusByCode  map[int]codes.Code
+	defaultStatus codes.Code
+	catalog       *errformatter.Catalog
+}
+
+func (c *converter) statusCode(err error, code int) codes.Code {
+	if code != errformatter.ValueCodeMissing {
+		statusCode, isMapped := c.statusByCode[code]
+		if isMapped {
+			return statusCode
+		}
+
+		if c.catalog != nil {
+			info, isRegistered := c.catalog.Lookup(code)
+			if isRegistered && info.GRPCCode != uint32(codes.OK) {
+				return codes.Code(info.GRPCCode)
+			}
+		}
+	}
+
+	// status code of wrapped error, e.g. error returned by downstream gRPC client, is kept
+	if st, isStatus := status.FromError(err); isStatus {
+		return st.Code()
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return c.defaultStatus
+	}
+}
+
+func newConverter(opts ...Option) *converter {
+	c := &converter{
+		statusByCode:  make(map[int]codes.Code),
+		defaultStatus: codes.Unknown,
+		catalog:       nil,
+	}
+
+	for i := range opts {
+		opts[i](c)
+	}
+
+	return c
+}
+
+// statusError - error restored from gRPC status, keeps status for status.FromError/status.Code functions...
+type statusError struct {
+	err    error
+	status *status.Status
+}
+
+// Error to string converter...
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns restored valued error...
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus returns gRPC status of error...
+func (e *statusError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// ToGRPCStatus converts error to gRPC status. Code, public code, scope, details, retry and severity values
+// of valued error are attached as errdetails.ErrorInfo status detail. Status code is taken from code mapping,
+// then from status of wrapped error, e.g. error of downstream gRPC call, default status code is used otherwise.
+// Values of custom kinds and stack trace of error are not attached and lost on conversion...
+func ToGRPCStatus(err error, opts ...Option) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	code := errformatter.ValuedErrorGetCode(err)
+	metadata := make(map[string]string)
+
+	if code != errformatter.ValueCodeMissing {
+		metadata[MetadataKeyCode] = strconv.Itoa(code)
+	}
+
+	if publicCode := errformatter.ValuedErrorGetPublicCode(err); publicCode != errformatter.ValueCodeMissing {
+		metadata[MetadataKeyPublicCode] = strconv.Itoa(publicCode)
+	}
+
+	if value, isExists := errformatter.ValuedErrorGetValue(err, errformatter.KindScope); isExists {
+		metadata[MetadataKeyScope] = value.GetScope()
+	}
+
+	if value, isExists := errformatter.ValuedErrorGetValue(err, errformatter.KindDetails); isExists {
+		details := make([]string, 0, len(value.GetDetails()))
+		for _, detail := range value.GetDetails() {
+			details = append(details, errformatter.Redact(detail))
+		}
+
+		rawDetails, _ := json.Marshal(details)
+		metadata[MetadataKeyDetails] = string(rawDetails)
+	}
+
+	if value, isExists := errformatter.ValuedErrorGetValue(err, errformatter.KindRetry); isExists {
+		rawRetry, _ := json.Marshal(value.GetRetry())
+		metadata[MetadataKeyRetry] = string(rawRetry)
+	}
+
+	if severity := errformatter.ErrorSeverity(err); severity != errformatter.SeverityUnknown {
+		metadata[MetadataKeySeverity] = severity.String()
+	}
+
+	// error without values, e.g. error returned by another gRPC client, keeps own status
+	if len(metadata) == 0 {
+		if st, isStatus := status.FromError(err); isStatus {
+			return st
+		}
+	}
+
+	st := status.New(newConverter(opts...).statusCode(err, code), errformatter.Redact(err.Error()))
+	if len(metadata) == 0 {
+		return st
+	}
+
+	detailedStatus, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   ErrorInfoReason,
+		Domain:   ErrorInfoDomain,
+		Metadata: metadata,
+	})
+	if detailsErr != nil {
+		return st
+	}
+
+	return detailedStatus
+}
+
+// FromGRPCStatus restores valued error from gRPC status. Returned error keeps gRPC status,
+// so status.Code/status.FromError functions work with it...
+func FromGRPCStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	var errorInfo *errdetails.ErrorInfo
+
+	for _, detail := range st.Details() {
+		info, isErrorInfo := detail.(*errdetails.ErrorInfo)
+		if isErrorInfo && info.GetDomain() == ErrorInfoDomain {
+			errorInfo = info
+
+			break
+		}
+	}
+
+	if errorInfo == nil {
+		return st.Err() //nolint:wrapcheck // it's ok - status error returned as is
+	}
+
+	metadata := errorInfo.GetMetadata()
+	values := make([]errformatter.Value, 0, len(metadata))
+
+	if code, err := strconv.Atoi(metadata[MetadataKeyCode]); err == nil {
+		values = append(values, errformatter.NewValue(errformatter.KindCode, code))
+	}
+
+	if publicCode, err := strconv.Atoi(metadata[MetadataKeyPublicCode]); err == nil {
+		values = append(values, errformatter.NewValue(errformatter.KindPublicCode, publicCode))
+	}
+
+	if scope, isExists := metadata[MetadataKeyScope]; isExists {
+		values = append(values, errformatter.NewValue(errformatter.KindScope, scope))
+	}
+
+	var details []string
+	if err := json.Unmarshal([]byte(metadata[MetadataKeyDetails]), &details); err == nil {
+		values = append(values, errformatter.NewValue(errformatter.KindDetails, details))
+	}
+
+	var retry errformatter.Retry
+	if err := json.Unmarshal([]byte(metadata[MetadataKeyRetry]), &retry); err == nil {
+		values = append(values, errformatter.NewValue(errformatter.KindRetry, retry))
+	}
+
+	if severity, isKnown := errformatter.ParseSeverity(metadata[MetadataKeySeverity]); isKnown {
+		values = append(values, errformatter.NewSeverityValue(severity))
+	}
+
+	return &statusError{
+		err:    errformatter.RestoreValuedError(st.Message(), nil, values...),
+		status: st,
+	}
+}
+
+// FromError restores valued error from error, returned by gRPC client...
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var restoredErr *statusError
+	if errors.As(err, &restoredErr) {
+		return err
+	}
+
+	st, isStatus := status.FromError(err)
+	if !isStatus {
+		return err
+	}
+
+	return FromGRPCStatus(st)
+}
@@ -0,0 +1,225 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package httperr
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+const (
+	// ContentTypeProblemJSON - content type of RFC 7807 problem details response...
+	ContentTypeProblemJSON = "application/problem+json"
+	// DefaultProblemType - type of problem without public code, as described in RFC 7807...
+	DefaultProblemType = "about:blank"
+)
+
+// Problem - RFC 7807 problem details. Contains only public information of error...
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Code     int    `json:"code,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// HandlerFunc - http handler function which returns error...
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Option is optional setting of problem renderer...
+type Option func(r *renderer)
+
+// WithCodeStatus sets HTTP status for errors with given error code...
+func WithCodeStatus(code int, status int) Option {
+	return func(r *renderer) {
+		r.statusByCode[code] = status
+	}
+}
+
+// WithStatusMapping sets HTTP statuses for errors by error codes...
+func WithStatusMapping(mapping map[int]int) Option {
+	return func(r *renderer) {
+		for code, status := range mapping {
+			r.statusByCode[code] = status
+		}
+	}
+}
+
+// WithCatalog sets catalog of error codes. HTTP status of not mapped code is taken from catalog...
+func WithCatalog(catalog *errformatter.Catalog) Option {
+	return func(r *renderer) {
+		r.catalog = catalog
+	}
+}
+
+// WithDefaultStatus sets HTTP status for errors without code or with not mapped code...
+func WithDefaultStatus(status int) Option {
+	return func(r *renderer) {
+		r.defaultStatus = status
+	}
+}
+
+// WithTypeBaseURI sets base URI of problem type. Type of problem is base URI with public code of error...
+func WithTypeBaseURI(baseURI string) Option {
+	return func(r *renderer) {
+		r.typeBaseURI = baseURI
+	}
+}
+
+type renderer struct {
+	statusByCode  map[int]int
+	defaultStatus int
+	typeBaseURI   string
+	catalog       *errformatter.Catalog
+}
+
+// Problem builds problem details of given error. Scope, details and text of error are hidden...
+func (r *renderer) Problem(err error) Problem {
+	status := r.status(err)
+
+	problem := Problem{
+		Type:     DefaultProblemType,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   "",
+		Code:     0,
+		Instance: "",
+	}
+
+	publicCode := errformatter.ValuedErrorGetPublicCode(err)
+	if publicCode != errformatter.ValueCodeMissing {
+		problem.Code = publicCode
+		problem.Type = r.typeBaseURI + strconv.Itoa(problem.Code)
+		problem.Detail, _ = errformatter.PublicMessage(publicCode)
+	}
+
+	return problem
+}
+
+func (r *renderer) status(err error) int {
+	code := errformatter.ValuedErrorGetCode(err)
+	if code == errformatter.ValueCodeMissing {
+		return r.defaultStatus
+	}
+
+	status, isMapped := r.statusByCode[code]
+	if isMapped {
+		return status
+	}
+
+	if r.catalog != nil {
+		info, isRegistered := r.catalog.Lookup(code)
+		if isRegistered && info.HTTPStatus > 0 {
+			return info.HTTPStatus
+		}
+	}
+
+	return r.defaultStatus
+}
+
+// responseWriter - wrapper of http.ResponseWriter, which tracks writing of response header...
+type responseWriter struct {
+	http.ResponseWriter
+
+	isHeaderWritten bool
+}
+
+// WriteHeader sends response header with given status code...
+func (w *responseWriter) WriteHeader(statusCode int) {
+	w.isHeaderWritten = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write writes data of response body, header is written implicitly by first call...
+func (w *responseWriter) Write(data []byte) (int, error) {
+	w.isHeaderWritten = true
+
+	//nolint:wrapcheck // it's ok - errors of wrapped writer must be returned as is
+	return w.ResponseWriter.Write(data)
+}
+
+// Unwrap returns wrapped http.ResponseWriter, used by http.ResponseController...
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Render writes problem details of given error as application/problem+json response.
+// Render does nothing if response header is already written by handler function of Handler middleware...
+func (r *renderer) Render(w http.ResponseWriter, req *http.Request, err error) {
+	if tracked, isTracked := w.(*responseWriter); isTracked && tracked.isHeaderWritten {
+		return
+	}
+
+	problem := r.Problem(err)
+	problem.Instance = req.URL.Path
+
+	w.Header().Set("Content-Type", ContentTypeProblemJSON)
+	w.WriteHeader(problem.Status)
+
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// Handler returns http.Handler, which renders error returned by given handler function...
+func (r *renderer) Handler(handlerFunc HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		tracked := &responseWriter{
+			ResponseWriter:  w,
+			isHeaderWritten: false,
+		}
+
+		err := handlerFunc(tracked, req)
+		if err != nil {
+			r.Render(tracked, req, err)
+		}
+	})
+}
+
+// NewRenderer returns RFC 7807 problem details renderer...
+func NewRenderer(opts ...Option) *renderer {
+	r := &renderer{
+		statusByCode:  make(map[int]int),
+		defaultStatus: http.StatusInternalServerError,
+		typeBaseURI:   "",
+		catalog:       nil,
+	}
+
+	for i := range opts {
+		opts[i](r)
+	}
+
+	return r
+}
@@ -0,0 +1,155 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package httperr
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+func TestProblemRenderer(t *testing.T) {
+	t.Run("valued error - public code and mapped status", func(t *testing.T) {
+		const expectedResult = `{"type":"https://errors.example.com/1042","title":"Not Found",` +
+			`"status":404,"code":1042,"instance":"/wallets/1"}` + "\n"
+
+		renderer := NewRenderer(
+			WithTypeBaseURI("https://errors.example.com/"),
+			WithCodeStatus(100500, http.StatusNotFound),
+		)
+
+		svc := errformatter.NewValuesErrorFormatter(
+			errformatter.NewValue(errformatter.KindScope, "wallet_storage"),
+			errformatter.NewValue(errformatter.KindPublicCode, 1042),
+		)
+
+		handler := renderer.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			return svc.ErrorWithCode(svc.NewError("wallet not found", "secret detail"), 100500)
+		})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/wallets/1", nil))
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("status not equal with expected. current: %d, expected: %d",
+				recorder.Code, http.StatusNotFound)
+		}
+
+		if contentType := recorder.Header().Get("Content-Type"); contentType != ContentTypeProblemJSON {
+			t.Errorf("content type not equal with expected. current: %s, expected: %s",
+				contentType, ContentTypeProblemJSON)
+		}
+
+		if recorder.Body.String() != expectedResult {
+			t.Errorf("body not equal with expected. current: %s, expected: %s",
+				recorder.Body.String(), expectedResult)
+		}
+
+		if strings.Contains(recorder.Body.String(), "wallet_storage") ||
+			strings.Contains(recorder.Body.String(), "secret detail") {
+			t.Errorf("body must not contain scope and details. current: %s", recorder.Body.String())
+		}
+	})
+
+	t.Run("plain error - default status and type", func(t *testing.T) {
+		renderer := NewRenderer(WithDefaultStatus(http.StatusBadGateway))
+
+		problem := renderer.Problem(errors.New("test error"))
+		if problem.Status != http.StatusBadGateway || problem.Type != DefaultProblemType || problem.Code != 0 {
+			t.Errorf("problem not equal with expected. current: %+v", problem)
+		}
+	})
+
+	t.Run("valued error - status from catalog", func(t *testing.T) {
+		catalog := errformatter.NewCatalog().MustRegister(errformatter.CodeInfo{
+			Code:       500,
+			Name:       "hsm_unreachable",
+			HTTPStatus: http.StatusServiceUnavailable,
+		})
+
+		err := errformatter.NewErrorFormatter().ErrorWithCode(errors.New("test error"), 500)
+
+		problem := NewRenderer(WithCatalog(catalog)).Problem(err)
+		if problem.Status != http.StatusServiceUnavailable {
+			t.Errorf("status not equal with expected. current: %d, expected: %d",
+				problem.Status, http.StatusServiceUnavailable)
+		}
+	})
+
+	t.Run("handler without error - response is not changed", func(t *testing.T) {
+		handler := NewRenderer().Handler(func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(http.StatusNoContent)
+
+			return nil
+		})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusNoContent {
+			t.Errorf("status not equal with expected. current: %d, expected: %d",
+				recorder.Code, http.StatusNoContent)
+		}
+	})
+
+	t.Run("handler with error after written response - problem is not rendered", func(t *testing.T) {
+		const expectedBody = "partial body"
+
+		handler := NewRenderer().Handler(func(w http.ResponseWriter, r *http.Request) error {
+			_, _ = w.Write([]byte(expectedBody))
+
+			return errors.New("test error")
+		})
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("status not equal with expected. current: %d, expected: %d",
+				recorder.Code, http.StatusOK)
+		}
+
+		if recorder.Body.String() != expectedBody {
+			t.Errorf("body not equal with expected. current: %s, expected: %s",
+				recorder.Body.String(), expectedBody)
+		}
+
+		if contentType := recorder.Header().Get("Content-Type"); contentType == ContentTypeProblemJSON {
+			t.Errorf("content type must not be %s", ContentTypeProblemJSON)
+		}
+	})
+}
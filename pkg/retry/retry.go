@@ -0,0 +1,231 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+const (
+	DefaultMaxAttempts  = 3
+	DefaultInitialDelay = 100 * time.Millisecond
+	DefaultMaxDelay     = 10 * time.Second
+	DefaultMultiplier   = 2
+	DefaultJitter       = 0.2
+)
+
+// KindAttempts - custom kind of valued error with count of attempts, made by Do function...
+//
+//nolint:gochecknoglobals // it's ok - custom kinds must be registered once
+var KindAttempts = errformatter.RegisterKind("retry_attempts", errformatter.WithKindType[int]())
+
+// Func - function, which is called by Do function until success or non-retryable error...
+type Func func(ctx context.Context) error
+
+// Clock - source of time for delays between attempts, can be replaced in tests...
+type Clock interface {
+	After(delay time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+// After waits for delay and then sends current time on returned channel...
+func (systemClock) After(delay time.Duration) <-chan time.Time {
+	return time.After(delay)
+}
+
+// Policy - settings of retry executor. Zero fields are replaced by default values, except Jitter -
+// zero Jitter disables randomization of delays...
+type Policy struct {
+	// MaxAttempts - max count of calls of function, including first call
+	MaxAttempts int
+	// InitialDelay - delay before second attempt
+	InitialDelay time.Duration
+	// MaxDelay - max delay between attempts, RetryAfter hints of errors are not limited by MaxDelay
+	MaxDelay time.Duration
+	// Multiplier - multiplier of delay of each next attempt
+	Multiplier float64
+	// Jitter - fraction of delay, by which delay is randomized, e.g. 0.2 is delay +/- 20%
+	Jitter float64
+	// Clock - source of time, system clock is used if clock is nil
+	Clock Clock
+	// Random - source of random numbers in [0, 1) for jitter, math/rand is used if random is nil
+	Random func() float64
+}
+
+// DefaultPolicy returns policy with default values...
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:  DefaultMaxAttempts,
+		InitialDelay: DefaultInitialDelay,
+		MaxDelay:     DefaultMaxDelay,
+		Multiplier:   DefaultMultiplier,
+		Jitter:       DefaultJitter,
+		Clock:        systemClock{},
+		Random:       rand.Float64, //nolint:gosec // it's ok - jitter of delays doesn't need crypto random
+	}
+}
+
+func (p Policy) withDefaults() Policy {
+	defaults := DefaultPolicy()
+
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaults.MaxAttempts
+	}
+
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = defaults.InitialDelay
+	}
+
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = defaults.MaxDelay
+	}
+
+	if p.Multiplier <= 0 {
+		p.Multiplier = defaults.Multiplier
+	}
+
+	if p.Clock == nil {
+		p.Clock = defaults.Clock
+	}
+
+	if p.Random == nil {
+		p.Random = defaults.Random
+	}
+
+	return p
+}
+
+// Delay returns delay before given attempt, attempt numbers start from 1. Delay before first attempt is zero...
+func (p Policy) Delay(attempt int) time.Duration {
+	if attempt <= 1 {
+		return 0
+	}
+
+	p = p.withDefaults()
+
+	delay := float64(p.InitialDelay)
+	for i := 2; i < attempt && delay < float64(p.MaxDelay); i++ {
+		delay *= p.Multiplier
+	}
+
+	delay = min(delay, float64(p.MaxDelay))
+
+	if p.Jitter > 0 {
+		delay *= 1 + p.Jitter*(2*p.Random()-1)
+	}
+
+	return time.Duration(max(delay, 0))
+}
+
+// Do calls function until success, non-retryable error or max attempts count. Retryability of errors is
+// classified by errformatter.IsRetryable, errformatter.RetryAfter hints of errors are respected.
+// Returned valued error wraps error of last attempt, contains count of attempts in KindAttempts value
+// and errors of all attempts, appended to details of error of last attempt...
+func Do(ctx context.Context, fn Func, policy Policy) error {
+	policy = policy.withDefaults()
+
+	var (
+		errs   []error
+		ctxErr error
+	)
+
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, err)
+
+		if attempt == policy.MaxAttempts || !errformatter.IsRetryable(err) {
+			break
+		}
+
+		delay := policy.Delay(attempt + 1)
+		if hint, isSet := errformatter.RetryAfter(err); isSet && hint > delay {
+			delay = hint
+		}
+
+		ctxErr = wait(ctx, policy.Clock, delay)
+		if ctxErr != nil {
+			break
+		}
+	}
+
+	return attemptsError(errs, ctxErr)
+}
+
+func wait(ctx context.Context, clock Clock, delay time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.After(delay):
+		return nil
+	}
+}
+
+// attemptsError returns valued error of last attempt with count of attempts. Errors of all attempts
+// are appended to details of error of last attempt...
+func attemptsError(errs []error, ctxErr error) error {
+	lastErr := errs[len(errs)-1]
+
+	details, _ := errformatter.ValuedErrorGet[[]string](lastErr, errformatter.KindDetails)
+	details = slices.Clip(details)
+
+	for i := range errs {
+		details = append(details, fmt.Sprintf("attempt %d: %s", i+1, errs[i]))
+	}
+
+	if ctxErr != nil {
+		lastErr = errformatter.NewMultiError(nil, lastErr, ctxErr)
+	}
+
+	return errformatter.ValuedErrorf(lastErr, []errformatter.Value{
+		errformatter.NewValue(KindAttempts, len(errs)),
+		errformatter.NewValue(errformatter.KindDetails, details),
+	}, "attempts: %d", len(errs))
+}
+
+// Attempts returns count of attempts, made by Do function, or zero if error is not returned by Do function...
+func Attempts(err error) int {
+	attempts, _ := errformatter.ValuedErrorGet[int](err, KindAttempts)
+
+	return attempts
+}
@@ -0,0 +1,221 @@
+/*
+ *
+ *
+ * MIT NON-AI License
+ *
+ * Copyright (c) 2022-2024 Aleksei Kotelnikov(gudron2s@gmail.com)
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of the software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions.
+ *
+ * The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+ *
+ * In addition, the following restrictions apply:
+ *
+ * 1. The Software and any modifications made to it may not be used for the purpose of training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining. This condition applies to any derivatives,
+ * modifications, or updates based on the Software code. Any usage of the Software in an AI-training dataset is considered a breach of this License.
+ *
+ * 2. The Software may not be included in any dataset used for training or improving machine learning algorithms,
+ * including but not limited to artificial intelligence, natural language processing, or data mining.
+ *
+ * 3. Any person or organization found to be in violation of these restrictions will be subject to legal action and may be held liable
+ * for any damages resulting from such use.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+ * DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE
+ * OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ *
+ */
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/crypto-bundle/bc-wallet-common-lib-errors/pkg/errformatter"
+)
+
+// fakeClock - clock, which records requested delays and fires timers immediately...
+type fakeClock struct {
+	delays []time.Duration
+}
+
+func (c *fakeClock) After(delay time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, delay)
+
+	result := make(chan time.Time, 1)
+	result <- time.Time{}
+
+	return result
+}
+
+func newTestPolicy(clock Clock) Policy {
+	return Policy{
+		MaxAttempts:  4,
+		InitialDelay: time.Second,
+		MaxDelay:     3 * time.Second,
+		Multiplier:   2,
+		Jitter:       0,
+		Clock:        clock,
+		Random:       nil,
+	}
+}
+
+func TestDo(t *testing.T) {
+	svc := errformatter.NewValuesErrorFormatter(errformatter.NewValue(errformatter.KindScope, "poller"))
+
+	t.Run("retryable errors - exponential backoff limited by max delay", func(t *testing.T) {
+		const expectedResult = "poller: node is unavailable -> attempts: 4"
+
+		errUnavailable := errors.New("node is unavailable")
+		clock := &fakeClock{delays: nil}
+
+		err := Do(context.Background(), func(context.Context) error {
+			return svc.ErrorWithRetry(errUnavailable, true)
+		}, newTestPolicy(clock))
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		expectedDelays := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}
+		if !slices.Equal(clock.delays, expectedDelays) {
+			t.Errorf("delays not equal with expected. current: %v, expected: %v",
+				clock.delays, expectedDelays)
+		}
+
+		if attempts := Attempts(err); attempts != 4 {
+			t.Errorf("count of attempts not equal with expected. current: %d, expected: %d", attempts, 4)
+		}
+
+		expectedDetails := []string{
+			"attempt 1: poller: node is unavailable",
+			"attempt 2: poller: node is unavailable",
+			"attempt 3: poller: node is unavailable",
+			"attempt 4: poller: node is unavailable",
+		}
+
+		details, _ := errformatter.ValuedErrorGet[[]string](err, errformatter.KindDetails)
+		if !slices.Equal(details, expectedDetails) {
+			t.Errorf("details not equal with expected. current: %s, expected: %s",
+				details, expectedDetails)
+		}
+
+		if !errors.Is(err, errUnavailable) {
+			t.Errorf("error must match error of attempt. current: %s, expected: %s", err, errUnavailable)
+		}
+	})
+
+	t.Run("valued error with details - details of last error are kept", func(t *testing.T) {
+		expectedDetails := []string{
+			"node_1",
+			"attempt 1: poller: node is unavailable -> node_1",
+			"attempt 2: poller: node is unavailable -> node_1",
+		}
+
+		policy := newTestPolicy(&fakeClock{delays: nil})
+		policy.MaxAttempts = 2
+
+		err := Do(context.Background(), func(context.Context) error {
+			return svc.ErrorWithRetry(svc.Error(errors.New("node is unavailable"), "node_1"), true)
+		}, policy)
+
+		details, _ := errformatter.ValuedErrorGet[[]string](err, errformatter.KindDetails)
+		if !slices.Equal(details, expectedDetails) {
+			t.Errorf("details not equal with expected. current: %s, expected: %s",
+				details, expectedDetails)
+		}
+	})
+
+	t.Run("retry after hint and success", func(t *testing.T) {
+		clock := &fakeClock{delays: nil}
+		calls := 0
+
+		err := Do(context.Background(), func(context.Context) error {
+			calls++
+			if calls == 1 {
+				return svc.ErrorWithRetryAfter(errors.New("rate limited"), 10*time.Second)
+			}
+
+			return nil
+		}, newTestPolicy(clock))
+		if err != nil {
+			t.Errorf("error must be nil after successful attempt. current: %s", err)
+		}
+
+		expectedDelays := []time.Duration{10 * time.Second}
+		if !slices.Equal(clock.delays, expectedDelays) {
+			t.Errorf("delays not equal with expected. current: %v, expected: %v",
+				clock.delays, expectedDelays)
+		}
+	})
+
+	t.Run("non-retryable error - no retries", func(t *testing.T) {
+		const expectedResult = "poller: invalid block -> attempts: 1"
+
+		clock := &fakeClock{delays: nil}
+
+		err := Do(context.Background(), func(context.Context) error {
+			return svc.NewError("invalid block")
+		}, newTestPolicy(clock))
+		if err.Error() != expectedResult {
+			t.Errorf("error text not equal with expected. current: %s, expected: %s",
+				err.Error(), expectedResult)
+		}
+
+		if len(clock.delays) != 0 || Attempts(err) != 1 {
+			t.Errorf("non-retryable error must not be retried. delays: %v, attempts: %d",
+				clock.delays, Attempts(err))
+		}
+	})
+
+	t.Run("canceled context - wait interrupted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Do(ctx, func(context.Context) error {
+			return context.DeadlineExceeded
+		}, Policy{
+			MaxAttempts:  3,
+			InitialDelay: time.Hour,
+			MaxDelay:     0,
+			Multiplier:   0,
+			Jitter:       0,
+			Clock:        nil,
+			Random:       nil,
+		})
+		if !errors.Is(err, context.Canceled) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("error must match context error and error of attempt. current: %s", err)
+		}
+
+		if attempts := Attempts(err); attempts != 1 {
+			t.Errorf("count of attempts not equal with expected. current: %d, expected: %d", attempts, 1)
+		}
+	})
+}
+
+func TestPolicy_Delay(t *testing.T) {
+	t.Run("jitter - delay randomized by fraction of delay", func(t *testing.T) {
+		policy := newTestPolicy(nil)
+		policy.Jitter = 0.5
+		policy.Random = func() float64 {
+			return 1
+		}
+
+		if delay := policy.Delay(2); delay != 1500*time.Millisecond {
+			t.Errorf("delay not equal with expected. current: %s, expected: %s",
+				delay, 1500*time.Millisecond)
+		}
+
+		if delay := policy.Delay(1); delay != 0 {
+			t.Errorf("delay of first attempt must be zero. current: %s", delay)
+		}
+	})
+}